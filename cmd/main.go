@@ -3,9 +3,11 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/Vovarama1992/go-utils/logger"
@@ -14,6 +16,9 @@ import (
 	"github.com/Vovarama1992/journalist/internal/domain"
 	"github.com/Vovarama1992/journalist/internal/domain/stations"
 	"github.com/Vovarama1992/journalist/internal/infra"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/Vovarama1992/journalist/migrations"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -34,7 +39,7 @@ func main() {
 
 	cookieFile := os.Getenv("YTDLP_COOKIES_FILE")
 	if cookieFile == "" {
-		log.Println("WARN: YTDLP_COOKIES_FILE is not set; yt-dlp may fail on YouTube")
+		log.Println("WARN: YTDLP_COOKIES_FILE is not set; yt-dlp uses only cookie jars from /api/admin/cookies")
 	}
 
 	dsn := os.Getenv("DATABASE_URL")
@@ -42,6 +47,11 @@ func main() {
 		panic("DATABASE_URL is not set")
 	}
 
+	// `server migrate [up | down N | status]` — только схема, без сервера
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(dsn, os.Args[2:]))
+	}
+
 	secret := os.Getenv("AUTH_SECRET")
 	if secret == "" {
 		panic("AUTH_SECRET is not set")
@@ -63,18 +73,115 @@ func main() {
 		panic("postgres ping failed: " + err.Error())
 	}
 
+	// MIGRATIONS — до сервисов; MIGRATE_ON_START=false → только `server migrate`
+	if os.Getenv("MIGRATE_ON_START") != "false" {
+		migrator, err := infra.NewMigrator(pool, migrations.FS)
+		if err != nil {
+			panic("migrations: " + err.Error())
+		}
+		n, err := migrator.Up(ctx)
+		if err != nil {
+			panic("migrations failed: " + err.Error())
+		}
+		log.Printf("[MIGRATE] applied=%d", n)
+	}
+
 	// SERVICES
 	authService := domain.NewAuthService(pool, secret)
 
 	mediaRepo := infra.NewPostgresMediaRepo(pool)
 	hMedia := delivery.NewMediaHandler(mediaRepo, zl)
-	stt := infra.NewYandexSTTService()
+
+	// GLOSSARY
+	glossaryRepo := infra.NewPostgresGlossaryRepo(pool)
+	hGlossary := delivery.NewGlossaryHandler(glossaryRepo, zl)
+
+	// USAGE LEDGER
+	usageRepo := infra.NewPostgresUsageRepo(pool)
+	hUsage := delivery.NewUsageHandler(usageRepo, zl)
+
+	stt := infra.NewYandexSTTService(usageRepo)
 
 	// GPT CLIENT
-	gptClient := infra.NewGPTClient()
+	// LLM_PROVIDER=openai — любой OpenAI-совместимый сервер (llama.cpp, Ollama)
+	var gptClient ports.LLMClient
+	llmModel := infra.OpenRouterModel
+	switch os.Getenv("LLM_PROVIDER") {
+	case "openai":
+		llmModel = os.Getenv("LLM_MODEL")
+		gptClient = infra.NewOpenAICompatClient(infra.OpenAICompatConfig{
+			BaseURL:    os.Getenv("LLM_BASE_URL"),
+			AuthHeader: os.Getenv("LLM_AUTH_HEADER"),
+			Model:      os.Getenv("LLM_MODEL"),
+			Provider:   os.Getenv("LLM_PROVIDER_NAME"),
+		}, usageRepo)
+	default:
+		gptClient = infra.NewGPTClient(usageRepo)
+	}
+
+	// RESULT CACHE: одинаковый вход STT / S5 не оплачиваем дважды
+	cacheMetrics := infra.NewCacheMetrics()
+	hCache := delivery.NewCacheHandler(cacheMetrics)
+
+	if cacheTTL := envDuration("CACHE_TTL", 30*24*time.Hour); cacheTTL > 0 {
+		resultCache := infra.NewPostgresResultCache(pool)
+		stt = infra.NewCachedSTT(stt, resultCache, cacheTTL, cacheMetrics)
+		gptClient = infra.NewCachedGPT(gptClient, resultCache, llmModel, cacheTTL, cacheMetrics)
+
+		go func() {
+			for range time.Tick(time.Hour) {
+				n, err := resultCache.PurgeExpired(ctx)
+				if err != nil {
+					log.Printf("[CACHE][PURGE][ERR] %v", err)
+					continue
+				}
+				log.Printf("[CACHE][PURGE] removed=%d", n)
+			}
+		}()
+	}
+
+	// STORAGE: архив аудио (оригиналы загрузок, WAV чанков)
+	var storage ports.ObjectStorage
+	if os.Getenv("STORAGE_BACKEND") == "s3" {
+		storage, err = infra.NewS3Storage(infra.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PathStyle: os.Getenv("S3_PATH_STYLE") != "false",
+		})
+	} else {
+		storageDir := os.Getenv("STORAGE_DIR")
+		if storageDir == "" {
+			storageDir = "/tmp/journalist/storage"
+		}
+		storage, err = infra.NewLocalStorage(storageDir)
+	}
+	if err != nil {
+		panic("storage: " + err.Error())
+	}
 
 	// STATIONS
-	s1 := stations.NewS1ResolveURL(cookieFile)
+	// COOKIE JARS: по доменам из БД, YTDLP_COOKIES_FILE — запасной
+	cookieDir := os.Getenv("COOKIE_DIR")
+	if cookieDir == "" {
+		cookieDir = "/tmp/journalist/cookies"
+	}
+	cookieJars := domain.NewCookieJarService(infra.NewPostgresCookieJarRepo(pool), cookieDir, cookieFile)
+	if err := cookieJars.Load(ctx); err != nil {
+		log.Printf("[COOKIES][LOAD][ERR] %v", err)
+	}
+	hCookie := delivery.NewCookieHandler(cookieJars, zl)
+
+	s1 := stations.NewS1ResolveURL(cookieJars)
+	s1meta := stations.NewS1Metadata(cookieJars)
+	uploadDir := os.Getenv("UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = "/tmp/journalist/uploads"
+	}
+	// локальные файлы как источник — только из SOURCE_FILE_DIR и загрузок
+	sources := stations.NewSourceRegistry(s1, s1meta, os.Getenv("SOURCE_FILE_DIR"), uploadDir)
 	s2 := stations.NewS2GrabPCM()
 	s3 := stations.NewS3PCMtoWAV()
 	s4 := stations.NewS4WAVtoText(stt)
@@ -82,10 +189,83 @@ func main() {
 	// MEDIA SERVICE (оркестратор)
 	mediaService := domain.NewMediaService(
 		mediaRepo,
-		s1, s2, s3, s4,
+		glossaryRepo,
+		storage,
+		sources, s2, s3, s4,
 		gptClient,
 	)
 
+	hSource := delivery.NewSourceHandler(mediaService, zl)
+	hFilter := delivery.NewFilterHandler(mediaService, zl)
+
+	// LIVE AUDIO ARCHIVE: непрерывная запись эфира в MP3-сегменты
+	audioArchive := domain.NewAudioArchiveService(infra.NewPostgresAudioRepo(pool), storage, sources, stations.NewS2Record())
+	mediaService.OnLiveStart(audioArchive.Record)
+	hAudio := delivery.NewAudioHandler(audioArchive, zl)
+
+	// POST-PROCESSING: редактура полного текста после окончания media
+	transcriptRepo := infra.NewPostgresTranscriptRepo(pool)
+	polisher := domain.NewPolishService(mediaRepo, transcriptRepo, gptClient)
+	hTranscript := delivery.NewTranscriptHandler(transcriptRepo, polisher, zl)
+
+	// ARTICLE DRAFTS
+	articleRepo := infra.NewPostgresArticleRepo(pool)
+	articleService := domain.NewArticleService(mediaRepo, transcriptRepo, articleRepo, gptClient)
+	hArticle := delivery.NewArticleHandler(articleRepo, articleService, zl)
+
+	// QUOTES
+	quoteRepo := infra.NewPostgresQuoteRepo(pool)
+	quoteService := domain.NewQuoteService(mediaRepo, quoteRepo, gptClient, storage)
+	hQuote := delivery.NewQuoteHandler(quoteRepo, quoteService, zl)
+
+	// ENTITIES: ENTITY_EXTRACTOR=rules — без LLM (даты, числа, имена собственные)
+	var entityExtractor ports.EntityExtractor = domain.NewLLMEntityExtractor(gptClient)
+	if os.Getenv("ENTITY_EXTRACTOR") == "rules" {
+		entityExtractor = domain.NewRuleEntityExtractor()
+	}
+	entityRepo := infra.NewPostgresEntityRepo(pool)
+	entityService := domain.NewEntityService(mediaRepo, entityRepo, entityExtractor)
+	mediaService.OnChunkDone(entityService.IndexChunk)
+	hEntity := delivery.NewEntityHandler(entityRepo, entityService, zl)
+
+	// FULL-TEXT SEARCH
+	hSearch := delivery.NewSearchHandler(infra.NewPostgresSearchRepo(pool), zl)
+
+	// UPLOADS: файл → та же обработка, что у записи
+	uploadService := domain.NewUploadService(mediaRepo, mediaService, storage, uploadDir, envInt64("UPLOAD_MAX_BYTES", 2<<30))
+	hUpload := delivery.NewUploadHandler(uploadService, zl)
+	go uploadService.RunCleanup(ctx)
+
+	// WATCHERS: эфир на канале → media и сессия в room; уведомления в room
+	// и, если задан WATCH_WEBHOOK_URL, во внешний webhook
+	sessions := domain.NewSessionManager(mediaService, mediaRepo)
+	// редактура — только когда источник действительно закончился (finished_at
+	// выставлен); обрыв WS оставляет расшифровку незавершённой
+	mediaService.OnEnded(func(mediaID int, reason string) {
+		sessions.Ended(mediaID, reason)
+		polisher.Enqueue(mediaID)
+	})
+	notifiers := domain.Notifiers{mediaService}
+	if hook := os.Getenv("WATCH_WEBHOOK_URL"); hook != "" {
+		notifiers = append(notifiers, infra.NewWebhookNotifier(hook))
+	}
+	watchService := domain.NewWatchService(
+		infra.NewPostgresWatchRepo(pool),
+		stations.NewS1LiveCheck(cookieJars),
+		sessions,
+		notifiers,
+	)
+	go watchService.Run(ctx)
+	hWatch := delivery.NewWatchHandler(watchService, zl)
+
+	// SCHEDULE: сессии по расписанию через тот же SessionManager
+	scheduleService := domain.NewScheduleService(infra.NewPostgresScheduleRepo(pool), sources, sessions)
+	go scheduleService.Run(ctx)
+	hSchedule := delivery.NewScheduleHandler(scheduleService, zl)
+
+	// QUESTION ANSWERING
+	hQA := delivery.NewQAHandler(domain.NewQAService(mediaRepo, gptClient), zl)
+
 	// WS HUB
 	hub := ws.NewHub()
 
@@ -94,23 +274,100 @@ func main() {
 		for ev := range mediaService.Events() {
 
 			type wsChunk struct {
+				Type    string `json:"type"`
 				MediaID int    `json:"mediaId"`
 				Chunk   int    `json:"chunk"`
 				Text    string `json:"text"`
 			}
 
-			payload, err := json.Marshal(wsChunk{
-				MediaID: ev.MediaID,
-				Chunk:   ev.ChunkNumber,
-				Text:    ev.Text,
-			})
+			type wsProgress struct {
+				Type     string  `json:"type"`
+				MediaID  int     `json:"mediaId"`
+				Progress float64 `json:"progress"`
+			}
+
+			type wsNotice struct {
+				Type    string `json:"type"`
+				MediaID int    `json:"mediaId,omitempty"`
+				Kind    string `json:"kind"`
+				Title   string `json:"title,omitempty"`
+				URL     string `json:"url,omitempty"`
+				Text    string `json:"text"`
+			}
+
+			type wsTracks struct {
+				Type     string                `json:"type"`
+				MediaID  int                   `json:"mediaId"`
+				Tracks   []models.AudioTrack   `json:"tracks"`
+				Selected models.AudioSelection `json:"selected"`
+			}
+			type wsError struct {
+				Type    string `json:"type"`
+				MediaID int    `json:"mediaId"`
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			}
+			type wsFinished struct {
+				Type    string `json:"type"`
+				MediaID int    `json:"mediaId"`
+				Reason  string `json:"reason"`
+			}
+
+			var payload []byte
+			var err error
+
+			switch {
+			case ev.Type == ports.EventProgress:
+				payload, err = json.Marshal(wsProgress{
+					Type:     ev.Type,
+					MediaID:  ev.MediaID,
+					Progress: ev.Progress,
+				})
+			case ev.Type == ports.EventNotice && ev.Notice != nil:
+				payload, err = json.Marshal(wsNotice{
+					Type:    ev.Type,
+					MediaID: ev.MediaID,
+					Kind:    ev.Notice.Kind,
+					Title:   ev.Notice.Title,
+					URL:     ev.Notice.URL,
+					Text:    ev.Notice.Text,
+				})
+			case ev.Type == ports.EventTracks && ev.Tracks != nil:
+				payload, err = json.Marshal(wsTracks{
+					Type:     ev.Type,
+					MediaID:  ev.MediaID,
+					Tracks:   ev.Tracks.Tracks,
+					Selected: ev.Tracks.Selected,
+				})
+			case ev.Type == ports.EventError:
+				payload, err = json.Marshal(wsError{
+					Type:    ev.Type,
+					MediaID: ev.MediaID,
+					Code:    ev.Code,
+					Message: ev.Text,
+				})
+			case ev.Type == ports.EventMediaFinished:
+				payload, err = json.Marshal(wsFinished{
+					Type:    ev.Type,
+					MediaID: ev.MediaID,
+					Reason:  ev.Text,
+				})
+			default:
+				payload, err = json.Marshal(wsChunk{
+					Type:    ports.EventChunk,
+					MediaID: ev.MediaID,
+					Chunk:   ev.ChunkNumber,
+					Text:    ev.Text,
+				})
+			}
 			if err != nil {
 				log.Printf("[SEND][ERR] json marshal failed: %v", err)
 				continue
 			}
 
-			log.Printf("[SEND] room=%s chunk=%d media=%d text=%.30s",
+			log.Printf("[SEND] room=%s type=%s chunk=%d media=%d text=%.30s",
 				ev.RoomID,
+				ev.Type,
 				ev.ChunkNumber,
 				ev.MediaID,
 				ev.Text,
@@ -128,12 +385,13 @@ func main() {
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "X-Auth"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Content-Type", "X-Auth", "Range"},
+		ExposedHeaders:   []string{"Content-Range", "Content-Length", "Accept-Ranges"},
 		AllowCredentials: true,
 	}))
 
-	delivery.RegisterRoutes(r, authHandler, authService, hMedia)
+	delivery.RegisterRoutes(r, authHandler, authService, hMedia, hUsage, hGlossary, hTranscript, hArticle, hQuote, hEntity, hQA, hCache, hUpload, hAudio, hCookie, hWatch, hSchedule, hSource, hFilter, hSearch)
 
 	// WS route — ТУТ ФИКС
 	r.Get("/ws", func(w http.ResponseWriter, r *http.Request) {
@@ -163,3 +421,100 @@ func main() {
 		})
 	}
 }
+
+func envDuration(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("WARN: %s=%q is not a duration, using %s", name, v, def)
+		return def
+	}
+	return d
+}
+
+func envInt64(name string, def int64) int64 {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		log.Printf("WARN: %s=%q is not a number, using %d", name, v, def)
+		return def
+	}
+	return n
+}
+
+// runMigrate — подкоманда migrate; код выхода процесса
+func runMigrate(dsn string, args []string) int {
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		log.Printf("[MIGRATE][FAIL] connect: %v", err)
+		return 1
+	}
+	defer pool.Close()
+
+	migrator, err := infra.NewMigrator(pool, migrations.FS)
+	if err != nil {
+		log.Printf("[MIGRATE][FAIL] %v", err)
+		return 1
+	}
+
+	cmd := "up"
+	if len(args) > 0 {
+		cmd = args[0]
+	}
+
+	switch cmd {
+	case "up":
+		n, err := migrator.Up(ctx)
+		if err != nil {
+			log.Printf("[MIGRATE][FAIL] %v", err)
+			return 1
+		}
+		log.Printf("[MIGRATE] applied=%d", n)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				log.Printf("[MIGRATE][FAIL] bad steps %q", args[1])
+				return 2
+			}
+		}
+		n, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Printf("[MIGRATE][FAIL] %v", err)
+			return 1
+		}
+		log.Printf("[MIGRATE] reverted=%d", n)
+
+	case "status":
+		list, err := migrator.Status(ctx)
+		if err != nil {
+			log.Printf("[MIGRATE][FAIL] %v", err)
+			return 1
+		}
+		for _, st := range list {
+			state := "pending"
+			if st.AppliedAt != nil {
+				state = "applied " + st.AppliedAt.Format(time.RFC3339)
+			}
+			if st.Modified {
+				state += " (modified)"
+			}
+			fmt.Printf("%03d_%-24s %s\n", st.Version, st.Name, state)
+		}
+
+	default:
+		log.Printf("usage: server migrate [up | down N | status]")
+		return 2
+	}
+	return 0
+}
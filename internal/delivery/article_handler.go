@@ -0,0 +1,200 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type ArticleHandler struct {
+	articles  ports.ArticleRepository
+	generator ports.ArticleGenerator
+	log       *logger.ZapLogger
+}
+
+func NewArticleHandler(
+	articles ports.ArticleRepository,
+	generator ports.ArticleGenerator,
+	log *logger.ZapLogger,
+) *ArticleHandler {
+	return &ArticleHandler{
+		articles:  articles,
+		generator: generator,
+		log:       log,
+	}
+}
+
+// POST /api/media/{id}/articles
+// {"chunkFrom": 0, "chunkTo": 0, "params": {"tone": "...", "length": "short"}}
+func (h *ArticleHandler) Generate(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		ChunkFrom int                  `json:"chunkFrom"`
+		ChunkTo   int                  `json:"chunkTo"`
+		Params    models.ArticleParams `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.ChunkFrom < 0 || req.ChunkTo < 0 || (req.ChunkTo > 0 && req.ChunkTo < req.ChunkFrom) {
+		http.Error(w, "invalid chunk range", http.StatusBadRequest)
+		return
+	}
+
+	draft, err := h.generator.Generate(r.Context(), mediaID, req.ChunkFrom, req.ChunkTo, req.Params)
+	if err != nil {
+		http.Error(w, "failed generate article: "+err.Error(), articleErrorStatus(err))
+		return
+	}
+
+	h.log.Log(logger.LogEntry{
+		Level:   "info",
+		Message: "article generated",
+		Fields: map[string]any{
+			"mediaID":   mediaID,
+			"articleID": draft.ID,
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(draft)
+}
+
+// GET /api/media/{id}/articles
+func (h *ArticleHandler) List(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.articles.ListArticles(r.Context(), mediaID)
+	if err != nil {
+		http.Error(w, "failed list articles: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"articles": list,
+	})
+}
+
+// GET /api/articles/{id}
+func (h *ArticleHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	draft, err := h.articles.GetArticle(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed get article: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if draft == nil {
+		http.Error(w, "article not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(draft)
+}
+
+// PUT /api/articles/{id} — ручная правка журналистом
+func (h *ArticleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Headlines []string              `json:"headlines"`
+		Lead      string                `json:"lead"`
+		Body      string                `json:"body"`
+		Quotes    []models.ArticleQuote `json:"quotes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	draft, err := h.articles.GetArticle(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed get article: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if draft == nil {
+		http.Error(w, "article not found", http.StatusNotFound)
+		return
+	}
+
+	draft.Headlines = req.Headlines
+	draft.Lead = req.Lead
+	draft.Body = req.Body
+	draft.Quotes = req.Quotes
+	draft.Edited = true
+
+	if err := h.articles.UpdateArticle(r.Context(), draft); err != nil {
+		http.Error(w, "failed update article: "+err.Error(), articleErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(draft)
+}
+
+// POST /api/articles/{id}/regenerate — тело необязательно: {"params": {...}}
+func (h *ArticleHandler) Regenerate(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Params *models.ArticleParams `json:"params"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid json: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	draft, err := h.generator.Regenerate(r.Context(), id, req.Params)
+	if err != nil {
+		http.Error(w, "failed regenerate article: "+err.Error(), articleErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if draft.ID != id {
+		// исходный правился руками — создана новая версия
+		w.WriteHeader(http.StatusCreated)
+	}
+	_ = json.NewEncoder(w).Encode(draft)
+}
+
+func articleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrArticleNotFound), errors.Is(err, ports.ErrMediaNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ports.ErrArticleNoText):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
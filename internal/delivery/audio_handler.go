@@ -0,0 +1,106 @@
+package delivery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type AudioHandler struct {
+	archive ports.AudioArchive
+	log     *logger.ZapLogger
+}
+
+func NewAudioHandler(archive ports.AudioArchive, log *logger.ZapLogger) *AudioHandler {
+	return &AudioHandler{
+		archive: archive,
+		log:     log,
+	}
+}
+
+// GET /api/media/{id}/audio[?t=секунды] — запись эфира одним MP3.
+// Range поддерживается (перемотка в <audio>); t — ответ с этого момента
+// media, т.е. тем же offset, что у чанков и цитат
+func (h *AudioHandler) Audio(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rs, segs, err := h.archive.Open(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed open audio: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rs.Close()
+
+	if len(segs) == 0 {
+		http.Error(w, "no audio for media", http.StatusNotFound)
+		return
+	}
+
+	if t := r.URL.Query().Get("t"); t != "" && r.Header.Get("Range") == "" {
+		sec, err := strconv.ParseFloat(t, 64)
+		if err != nil || sec < 0 {
+			http.Error(w, "invalid t", http.StatusBadRequest)
+			return
+		}
+		pos := h.archive.ByteAt(segs, int64(sec*1000))
+		r.Header.Set("Range", fmt.Sprintf("bytes=%d-", pos))
+	}
+
+	w.Header().Set("Content-Type", "audio/mpeg")
+	http.ServeContent(w, r, "", segs[len(segs)-1].CreatedAt, rs)
+}
+
+// GET /api/media/{id}/audio/segments — карта записи: время ↔ байты, разрывы
+func (h *AudioHandler) Segments(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rs, segs, err := h.archive.Open(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed list audio: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rs.Close()
+
+	type segmentJSON struct {
+		Seq        int       `json:"seq"`
+		OffsetMs   int64     `json:"offsetMs"`
+		DurationMs int       `json:"durationMs"`
+		ByteStart  int64     `json:"byteStart"`
+		Bytes      int64     `json:"bytes"`
+		CreatedAt  time.Time `json:"createdAt"`
+	}
+
+	out := make([]segmentJSON, 0, len(segs))
+	var pos int64
+	for _, s := range segs {
+		out = append(out, segmentJSON{
+			Seq:        s.Seq,
+			OffsetMs:   s.OffsetMs,
+			DurationMs: s.DurationMs,
+			ByteStart:  pos,
+			Bytes:      s.Bytes,
+			CreatedAt:  s.CreatedAt,
+		})
+		pos += s.Bytes
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"mediaID":  id,
+		"bytes":    pos,
+		"segments": out,
+	})
+}
@@ -0,0 +1,24 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type CacheHandler struct {
+	stats ports.CacheStats
+}
+
+func NewCacheHandler(stats ports.CacheStats) *CacheHandler {
+	return &CacheHandler{stats: stats}
+}
+
+// GET /api/cache/stats — hit/miss с момента старта процесса
+func (h *CacheHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"caches": h.stats.Stats(),
+	})
+}
@@ -0,0 +1,163 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+const maxCookieJarBytes = 1 << 20
+
+type CookieHandler struct {
+	jars ports.CookieJarManager
+	log  *logger.ZapLogger
+}
+
+func NewCookieHandler(jars ports.CookieJarManager, log *logger.ZapLogger) *CookieHandler {
+	return &CookieHandler{
+		jars: jars,
+		log:  log,
+	}
+}
+
+type cookieJarReq struct {
+	Domain  string `json:"domain"`
+	Name    string `json:"name"`
+	Content string `json:"content"` // Netscape cookies.txt
+	TestURL string `json:"testURL"` // страница, которую yt-dlp должен открыть с этим jar
+}
+
+// GET /api/admin/cookies — без содержимого
+func (h *CookieHandler) List(w http.ResponseWriter, r *http.Request) {
+	jars, err := h.jars.List(r.Context())
+	if err != nil {
+		http.Error(w, "failed list cookie jars: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"jars": jars,
+	})
+}
+
+// POST /api/admin/cookies — JSON или multipart (file + domain, name, testURL).
+// Jar проверяется test resolve'ом и, если прошёл, сразу заменяет активный
+func (h *CookieHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	req, err := readCookieJarReq(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jar, err := h.jars.Upload(r.Context(), req.Domain, req.Name, req.Content, req.TestURL)
+
+	status := http.StatusCreated
+	switch {
+	case err == nil:
+	case errors.Is(err, ports.ErrCookieJarInvalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, ports.ErrCookieJarCheck) && jar != nil:
+		// jar сохранён со status=failed — видно, почему не подошёл
+		status = http.StatusUnprocessableEntity
+	default:
+		http.Error(w, "failed upload cookie jar: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.log.Log(logger.LogEntry{
+		Level:   "info",
+		Message: "cookie jar uploaded",
+		Fields: map[string]any{
+			"domain": jar.Domain,
+			"jarID":  jar.ID,
+			"status": jar.Status,
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(jar)
+}
+
+// POST /api/admin/cookies/{id}/activate — откат на прежний проверенный jar
+func (h *CookieHandler) Activate(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jar, err := h.jars.Activate(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), cookieErrStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(jar)
+}
+
+// DELETE /api/admin/cookies/{id}
+func (h *CookieHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.jars.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), cookieErrStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func cookieErrStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrCookieJarNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ports.ErrCookieJarInvalid):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func readCookieJarReq(r *http.Request) (*cookieJarReq, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		var req cookieJarReq
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxCookieJarBytes)).Decode(&req); err != nil {
+			return nil, errors.New("invalid json: " + err.Error())
+		}
+		return &req, nil
+	}
+
+	if err := r.ParseMultipartForm(maxCookieJarBytes); err != nil {
+		return nil, errors.New("bad multipart: " + err.Error())
+	}
+
+	f, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, errors.New("missing file")
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, maxCookieJarBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return &cookieJarReq{
+		Domain:  r.FormValue("domain"),
+		Name:    r.FormValue("name"),
+		Content: string(content),
+		TestURL: r.FormValue("testURL"),
+	}, nil
+}
@@ -0,0 +1,130 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type EntityHandler struct {
+	entities ports.EntityRepository
+	indexer  ports.EntityIndexer
+	log      *logger.ZapLogger
+}
+
+func NewEntityHandler(
+	entities ports.EntityRepository,
+	indexer ports.EntityIndexer,
+	log *logger.ZapLogger,
+) *EntityHandler {
+	return &EntityHandler{
+		entities: entities,
+		indexer:  indexer,
+		log:      log,
+	}
+}
+
+type entityMentionGroup struct {
+	MediaID   int              `json:"mediaID"`
+	SourceURL string           `json:"sourceURL"`
+	Chunks    []map[string]any `json:"chunks"`
+}
+
+// GET /api/entities?q=собянин&kind=person&limit=200
+// Все трансляции, где упоминалась сущность, со ссылками на чанки.
+func (h *EntityHandler) Search(w http.ResponseWriter, r *http.Request) {
+	q := models.NormalizeEntity(r.URL.Query().Get("q"))
+	if q == "" {
+		http.Error(w, "missing q", http.StatusBadRequest)
+		return
+	}
+
+	limit, err := queryInt(r, "limit", 200)
+	if err != nil || limit <= 0 || limit > 1000 {
+		http.Error(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+
+	mentions, err := h.entities.FindMentions(r.Context(), q, strings.TrimSpace(r.URL.Query().Get("kind")), limit)
+	if err != nil {
+		http.Error(w, "failed search entities: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	groups := []*entityMentionGroup{}
+	byMedia := map[int]*entityMentionGroup{}
+	for _, m := range mentions {
+		g, ok := byMedia[m.MediaID]
+		if !ok {
+			g = &entityMentionGroup{MediaID: m.MediaID, SourceURL: m.SourceURL}
+			byMedia[m.MediaID] = g
+			groups = append(groups, g)
+		}
+		g.Chunks = append(g.Chunks, map[string]any{
+			"chunk": m.ChunkNumber,
+			"kind":  m.Kind,
+			"value": m.Value,
+			"text":  m.Text,
+			"url":   chunkURL(m.MediaID, m.ChunkNumber),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"query": q,
+		"media": groups,
+	})
+}
+
+// GET /api/media/{id}/entities
+func (h *EntityHandler) ListMedia(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.entities.ListMediaEntities(r.Context(), mediaID)
+	if err != nil {
+		http.Error(w, "failed list entities: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"entities": list,
+	})
+}
+
+// POST /api/media/{id}/entities — переиндексировать все чанки media
+func (h *EntityHandler) Reindex(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n, err := h.indexer.IndexMedia(r.Context(), mediaID)
+	if err != nil {
+		http.Error(w, "failed index entities: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.log.Log(logger.LogEntry{
+		Level:   "info",
+		Message: "media entities reindexed",
+		Fields: map[string]any{
+			"mediaID": mediaID,
+			"chunks":  n,
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"chunks": n,
+	})
+}
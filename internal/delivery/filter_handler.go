@@ -0,0 +1,75 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type FilterHandler struct {
+	filters ports.AudioFilterManager
+	log     *logger.ZapLogger
+}
+
+func NewFilterHandler(filters ports.AudioFilterManager, log *logger.ZapLogger) *FilterHandler {
+	return &FilterHandler{
+		filters: filters,
+		log:     log,
+	}
+}
+
+// GET /api/audio/filters — пресеты и их цепочки ffmpeg
+func (h *FilterHandler) List(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"presets": h.filters.FilterPresets(),
+	})
+}
+
+// PUT /api/media/{id}/filter {"preset": "field"} — идущая сессия применит
+// со следующего окна
+func (h *FilterHandler) Set(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Preset string `json:"preset"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Preset == "" {
+		http.Error(w, "invalid json: preset is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.filters.SetFilter(r.Context(), id, req.Preset); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ports.ErrFilterUnknown):
+			status = http.StatusBadRequest
+		case errors.Is(err, ports.ErrMediaNotFound):
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	h.log.Log(logger.LogEntry{
+		Level:   "info",
+		Message: "media audio filter set",
+		Fields: map[string]any{
+			"mediaID": id,
+			"preset":  req.Preset,
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"mediaID": id,
+		"preset":  req.Preset,
+	})
+}
@@ -0,0 +1,155 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type GlossaryHandler struct {
+	glossary ports.GlossaryRepository
+	log      *logger.ZapLogger
+}
+
+func NewGlossaryHandler(glossary ports.GlossaryRepository, log *logger.ZapLogger) *GlossaryHandler {
+	return &GlossaryHandler{
+		glossary: glossary,
+		log:      log,
+	}
+}
+
+type glossaryReq struct {
+	MediaID  *int     `json:"mediaID"` // null → для всех media
+	Term     string   `json:"term"`
+	Spelling string   `json:"spelling"`
+	Aliases  []string `json:"aliases"`
+}
+
+func (req *glossaryReq) toModel() (*models.GlossaryTerm, bool) {
+	t := &models.GlossaryTerm{
+		MediaID:  req.MediaID,
+		Term:     strings.TrimSpace(req.Term),
+		Spelling: strings.TrimSpace(req.Spelling),
+	}
+	for _, a := range req.Aliases {
+		if a = strings.TrimSpace(a); a != "" {
+			t.Aliases = append(t.Aliases, a)
+		}
+	}
+	if t.Spelling == "" {
+		t.Spelling = t.Term
+	}
+	return t, t.Term != ""
+}
+
+// GET /api/glossary?mediaID=12 — термины media + общие
+func (h *GlossaryHandler) List(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := queryInt(r, "mediaID", 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	terms, err := h.glossary.ListTerms(r.Context(), mediaID)
+	if err != nil {
+		http.Error(w, "failed list glossary: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"terms": terms,
+	})
+}
+
+// POST /api/glossary
+func (h *GlossaryHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req glossaryReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	t, ok := req.toModel()
+	if !ok {
+		http.Error(w, "term is required", http.StatusBadRequest)
+		return
+	}
+
+	t, err := h.glossary.InsertTerm(r.Context(), t)
+	if err != nil {
+		http.Error(w, "failed create term: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.log.Log(logger.LogEntry{
+		Level:   "info",
+		Message: "glossary term created",
+		Fields: map[string]any{
+			"id":   t.ID,
+			"term": t.Term,
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(t)
+}
+
+// PUT /api/glossary/{id} — полная замена; mediaID не указан → термин общий
+func (h *GlossaryHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req glossaryReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	t, ok := req.toModel()
+	if !ok {
+		http.Error(w, "term is required", http.StatusBadRequest)
+		return
+	}
+	t.ID = id
+
+	if err := h.glossary.UpdateTerm(r.Context(), t); err != nil {
+		http.Error(w, "failed update term: "+err.Error(), glossaryErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(t)
+}
+
+// DELETE /api/glossary/{id}
+func (h *GlossaryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.glossary.DeleteTerm(r.Context(), id); err != nil {
+		http.Error(w, "failed delete term: "+err.Error(), glossaryErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func glossaryErrorStatus(err error) int {
+	if errors.Is(err, ports.ErrGlossaryTermNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
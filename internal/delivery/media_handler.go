@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/models"
 	"github.com/Vovarama1992/journalist/internal/ports"
 	"github.com/go-chi/chi/v5"
 )
@@ -56,3 +57,123 @@ func (h *MediaHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
 		"text": text,
 	})
 }
+
+// GET /api/media/{id}/chunks/{n}
+func (h *MediaHandler) GetChunk(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n, err := urlID(r, "n")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, err := h.media.GetChunk(r.Context(), id, n)
+	if err != nil {
+		http.Error(w, "failed get chunk: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if c == nil {
+		http.Error(w, "chunk not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"mediaID":    c.MediaID,
+		"chunk":      c.ChunkNumber,
+		"status":     c.Status,
+		"text":       c.Text,
+		"raw":        c.RawText,
+		"capturedAt": c.CapturedAt,
+		"offsetMs":   c.OffsetMs,
+		"durationMs": c.DurationMs,
+		// предобработка: пресет и RMS до / после, dBFS
+		"audioFilter": c.AudioFilter,
+		"rmsBeforeDb": c.RMSBeforeDB,
+		"rmsAfterDb":  c.RMSAfterDB,
+	})
+}
+
+// GET /api/media?limit=&offset=
+func (h *MediaHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, err := queryInt(r, "limit", 50)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	list, err := h.media.ListMedia(r.Context(), limit, offset)
+	if err != nil {
+		http.Error(w, "failed list media: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	out := make([]map[string]any, 0, len(list))
+	for i := range list {
+		out = append(out, mediaJSON(&list[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// GET /api/media/{id}
+func (h *MediaHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m, err := h.media.GetMediaByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed get media: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if m == nil {
+		http.Error(w, "media not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(mediaJSON(m))
+}
+
+func mediaJSON(m *models.Media) map[string]any {
+	return map[string]any{
+		"id":           m.ID,
+		"sourceURL":    m.SourceURL,
+		"type":         m.Type,
+		"createdAt":    m.CreatedAt,
+		"title":        m.Title,
+		"uploader":     m.Uploader,
+		"durationSec":  m.DurationSec,
+		"isLive":       m.IsLive,
+		"liveStatus":   m.LiveStatus,
+		"thumbnailURL": m.ThumbnailURL,
+		"publishedAt":  m.PublishedAt,
+		"audioFilter":  m.AudioFilter,
+		"finishedAt":   m.FinishedAt,
+		"endReason":    m.EndReason,
+	}
+}
+
+func chunkURL(mediaID, chunkNumber int) string {
+	return "/api/media/" + strconv.Itoa(mediaID) + "/chunks/" + strconv.Itoa(chunkNumber)
+}
@@ -0,0 +1,37 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// urlID — целочисленный {name} из пути
+func urlID(r *http.Request, name string) (int, error) {
+	v := chi.URLParam(r, name)
+	if v == "" {
+		return 0, errors.New("missing " + name)
+	}
+
+	id, err := strconv.Atoi(v)
+	if err != nil || id <= 0 {
+		return 0, errors.New("invalid " + name)
+	}
+	return id, nil
+}
+
+// queryInt — необязательный целочисленный query-параметр; пусто → def
+func queryInt(r *http.Request, name string, def int) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, errors.New("invalid " + name)
+	}
+	return n, nil
+}
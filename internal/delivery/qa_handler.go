@@ -0,0 +1,60 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type QAHandler struct {
+	qa  ports.QuestionAnswerer
+	log *logger.ZapLogger
+}
+
+func NewQAHandler(qa ports.QuestionAnswerer, log *logger.ZapLogger) *QAHandler {
+	return &QAHandler{
+		qa:  qa,
+		log: log,
+	}
+}
+
+// POST /api/media/{id}/ask {"question": "что мэр сказал про бюджет?"}
+func (h *QAHandler) Ask(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Question string `json:"question"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Question == "" {
+		http.Error(w, "question is required", http.StatusBadRequest)
+		return
+	}
+
+	answer, err := h.qa.Ask(r.Context(), mediaID, req.Question)
+	if err != nil {
+		http.Error(w, "failed answer: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.log.Log(logger.LogEntry{
+		Level:   "info",
+		Message: "media question answered",
+		Fields: map[string]any{
+			"mediaID":   mediaID,
+			"citations": len(answer.Citations),
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(answer)
+}
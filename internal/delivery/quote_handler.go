@@ -0,0 +1,118 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type QuoteHandler struct {
+	quotes    ports.QuoteRepository
+	extractor ports.QuoteExtractor
+	log       *logger.ZapLogger
+}
+
+func NewQuoteHandler(
+	quotes ports.QuoteRepository,
+	extractor ports.QuoteExtractor,
+	log *logger.ZapLogger,
+) *QuoteHandler {
+	return &QuoteHandler{
+		quotes:    quotes,
+		extractor: extractor,
+		log:       log,
+	}
+}
+
+// POST /api/media/{id}/quotes — новая выборка цитат (заменяет прежнюю)
+func (h *QuoteHandler) Extract(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	quotes, err := h.extractor.Extract(r.Context(), mediaID)
+	if err != nil {
+		http.Error(w, "failed extract quotes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.log.Log(logger.LogEntry{
+		Level:   "info",
+		Message: "quotes extracted",
+		Fields: map[string]any{
+			"mediaID": mediaID,
+			"count":   len(quotes),
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"quotes": withClipURLs(quotes),
+	})
+}
+
+// GET /api/media/{id}/quotes
+func (h *QuoteHandler) List(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	quotes, err := h.quotes.ListQuotes(r.Context(), mediaID)
+	if err != nil {
+		http.Error(w, "failed list quotes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"quotes": withClipURLs(quotes),
+	})
+}
+
+// GET /api/quotes/{id}/audio — WAV-клип цитаты
+func (h *QuoteHandler) Audio(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	wav, err := h.extractor.Clip(r.Context(), id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ports.ErrQuoteNotFound) || errors.Is(err, ports.ErrQuoteAudioNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, "failed get clip: "+err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "audio/wav")
+	w.Header().Set("Content-Length", strconv.Itoa(len(wav)))
+	_, _ = w.Write(wav)
+}
+
+type quoteResp struct {
+	models.Quote
+	AudioURL string `json:"audioURL"`
+}
+
+func withClipURLs(quotes []models.Quote) []quoteResp {
+	out := make([]quoteResp, 0, len(quotes))
+	for _, q := range quotes {
+		out = append(out, quoteResp{
+			Quote:    q,
+			AudioURL: "/api/quotes/" + strconv.Itoa(q.ID) + "/audio",
+		})
+	}
+	return out
+}
@@ -5,11 +5,115 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-func RegisterRoutes(r chi.Router, hAuth *AuthHandler, auth ports.AuthService, hMedia *MediaHandler) {
+func RegisterRoutes(
+	r chi.Router,
+	hAuth *AuthHandler,
+	auth ports.AuthService,
+	hMedia *MediaHandler,
+	hUsage *UsageHandler,
+	hGlossary *GlossaryHandler,
+	hTranscript *TranscriptHandler,
+	hArticle *ArticleHandler,
+	hQuote *QuoteHandler,
+	hEntity *EntityHandler,
+	hQA *QAHandler,
+	hCache *CacheHandler,
+	hUpload *UploadHandler,
+	hAudio *AudioHandler,
+	hCookie *CookieHandler,
+	hWatch *WatchHandler,
+	hSchedule *ScheduleHandler,
+	hSource *SourceHandler,
+	hFilter *FilterHandler,
+	hSearch *SearchHandler,
+) {
 
 	// login
 	r.Post("/api/login", hAuth.Login)
 
 	// media history
 	r.Get("/api/media-history/{id}", hMedia.GetHistory)
+	r.Get("/api/media", hMedia.List)
+	r.Get("/api/media/{id}", hMedia.Get)
+	r.Get("/api/media/{id}/chunks/{n}", hMedia.GetChunk)
+
+	// запись эфира: MP3 с Range / ?t=
+	r.Get("/api/media/{id}/audio", hAudio.Audio)
+	r.Get("/api/media/{id}/audio/segments", hAudio.Segments)
+
+	// аудиодорожки источника — выбрать до старта сессии
+	r.Get("/api/sources/tracks", hSource.Tracks)
+
+	// предобработка звука перед STT
+	r.Get("/api/audio/filters", hFilter.List)
+	r.Put("/api/media/{id}/filter", hFilter.Set)
+
+	// upload файлов (диктофон и т.п.), в т.ч. по частям
+	r.Post("/api/media/upload", hUpload.Upload)
+	r.Get("/api/media/upload/{uploadId}", hUpload.Status)
+
+	// edited transcript
+	r.Get("/api/media/{id}/transcript", hTranscript.Get)
+	r.Post("/api/media/{id}/polish", hTranscript.Polish)
+
+	// article drafts
+	r.Post("/api/media/{id}/articles", hArticle.Generate)
+	r.Get("/api/media/{id}/articles", hArticle.List)
+	r.Get("/api/articles/{id}", hArticle.Get)
+	r.Put("/api/articles/{id}", hArticle.Update)
+	r.Post("/api/articles/{id}/regenerate", hArticle.Regenerate)
+
+	// quotes
+	r.Post("/api/media/{id}/quotes", hQuote.Extract)
+	r.Get("/api/media/{id}/quotes", hQuote.List)
+	r.Get("/api/quotes/{id}/audio", hQuote.Audio)
+
+	// full-text по всем транскриптам
+	r.Get("/api/search", hSearch.Search)
+
+	// entities
+	r.Get("/api/entities", hEntity.Search)
+	r.Get("/api/media/{id}/entities", hEntity.ListMedia)
+	r.Post("/api/media/{id}/entities", hEntity.Reindex)
+
+	// question answering
+	r.Post("/api/media/{id}/ask", hQA.Ask)
+
+	// STT / LLM cache
+	r.Get("/api/cache/stats", hCache.Stats)
+
+	// usage / cost
+	r.Get("/api/usage/media", hUsage.ByMedia)
+	r.Get("/api/usage/daily", hUsage.ByDay)
+	r.Get("/api/usage/users", hUsage.ByUser)
+
+	// glossary
+	r.Get("/api/glossary", hGlossary.List)
+	r.Post("/api/glossary", hGlossary.Create)
+	r.Put("/api/glossary/{id}", hGlossary.Update)
+	r.Delete("/api/glossary/{id}", hGlossary.Delete)
+
+	// watchers: эфир на канале → сессия в room
+	r.Get("/api/watchers", hWatch.List)
+	r.Post("/api/watchers", hWatch.Create)
+	r.Put("/api/watchers/{id}", hWatch.Update)
+	r.Delete("/api/watchers/{id}", hWatch.Delete)
+	r.Post("/api/watchers/{id}/check", hWatch.Check)
+
+	// запланированные сессии (пресс-конференции и т.п.)
+	r.Get("/api/schedules", hSchedule.List)
+	r.Post("/api/schedules", hSchedule.Create)
+	r.Get("/api/schedules/{id}", hSchedule.Get)
+	r.Put("/api/schedules/{id}", hSchedule.Update)
+	r.Delete("/api/schedules/{id}", hSchedule.Cancel)
+
+	// admin: cookie jars для yt-dlp — только с токеном
+	r.Group(func(r chi.Router) {
+		r.Use(AuthMiddleware(auth))
+
+		r.Get("/api/admin/cookies", hCookie.List)
+		r.Post("/api/admin/cookies", hCookie.Upload)
+		r.Post("/api/admin/cookies/{id}/activate", hCookie.Activate)
+		r.Delete("/api/admin/cookies/{id}", hCookie.Delete)
+	})
 }
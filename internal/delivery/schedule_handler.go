@@ -0,0 +1,180 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type ScheduleHandler struct {
+	schedules ports.ScheduleManager
+	log       *logger.ZapLogger
+}
+
+func NewScheduleHandler(schedules ports.ScheduleManager, log *logger.ZapLogger) *ScheduleHandler {
+	return &ScheduleHandler{
+		schedules: schedules,
+		log:       log,
+	}
+}
+
+type scheduleReq struct {
+	URL            string    `json:"url"`
+	RoomID         string    `json:"roomID"`
+	User           string    `json:"user"`
+	Language       string    `json:"language"` // ru-RU, en-US, ...
+	Prompt         string    `json:"prompt"`
+	StartAt        time.Time `json:"startAt"` // RFC 3339
+	MaxDurationSec int       `json:"maxDurationSec"`
+}
+
+func (req *scheduleReq) session() *models.ScheduledSession {
+	return &models.ScheduledSession{
+		URL:            req.URL,
+		RoomID:         req.RoomID,
+		User:           req.User,
+		Language:       req.Language,
+		Prompt:         req.Prompt,
+		StartAt:        req.StartAt,
+		MaxDurationSec: req.MaxDurationSec,
+	}
+}
+
+// GET /api/schedules?status=&limit=&offset=
+func (h *ScheduleHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, err := queryInt(r, "limit", 50)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	list, err := h.schedules.List(r.Context(), r.URL.Query().Get("status"), limit, offset)
+	if err != nil {
+		http.Error(w, "failed list schedules: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"schedules": list,
+	})
+}
+
+// POST /api/schedules
+func (h *ScheduleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req scheduleReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+
+	sc := req.session()
+	if err := h.schedules.Create(r.Context(), sc); err != nil {
+		http.Error(w, err.Error(), scheduleErrStatus(err))
+		return
+	}
+
+	h.log.Log(logger.LogEntry{
+		Level:   "info",
+		Message: "session scheduled",
+		Fields: map[string]any{
+			"scheduleID": sc.ID,
+			"url":        sc.URL,
+			"roomID":     sc.RoomID,
+			"startAt":    sc.StartAt,
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(sc)
+}
+
+// GET /api/schedules/{id}
+func (h *ScheduleHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sc, err := h.schedules.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), scheduleErrStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(sc)
+}
+
+// PUT /api/schedules/{id} — только пока сессия не стартовала
+func (h *ScheduleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req scheduleReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+
+	sc := req.session()
+	sc.ID = id
+	if err := h.schedules.Update(r.Context(), sc); err != nil {
+		http.Error(w, err.Error(), scheduleErrStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(sc)
+}
+
+// DELETE /api/schedules/{id} — отмена; идущая сессия останавливается, запись остаётся
+func (h *ScheduleHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sc, err := h.schedules.Cancel(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), scheduleErrStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(sc)
+}
+
+func scheduleErrStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrScheduleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ports.ErrScheduleInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, ports.ErrScheduleState):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
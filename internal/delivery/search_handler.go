@@ -0,0 +1,117 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+const maxSearchQuery = 500
+
+type SearchHandler struct {
+	search ports.TranscriptSearcher
+	log    *logger.ZapLogger
+}
+
+func NewSearchHandler(search ports.TranscriptSearcher, log *logger.ZapLogger) *SearchHandler {
+	return &SearchHandler{
+		search: search,
+		log:    log,
+	}
+}
+
+// GET /api/search?q="бюджет города" -собянин&media=12,15&from=2025-01-01&to=2025-02-01&limit=50&offset=0
+// Поиск по всем транскриптам: фраза — в кавычках, or — любое из, минус — исключить.
+// from / to — дата трансляции (YYYY-MM-DD), to включительно.
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	f, err := parseSearchFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hits, total, err := h.search.Search(r.Context(), f)
+	if err != nil {
+		h.log.Log(logger.LogEntry{
+			Level:   "error",
+			Message: "transcript search failed",
+			Error:   err,
+			Fields:  map[string]any{"q": f.Query},
+		})
+		http.Error(w, "failed search: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type hitJSON struct {
+		models.SearchHit
+		URL string `json:"url"`
+	}
+	out := make([]hitJSON, 0, len(hits))
+	for _, hit := range hits {
+		out = append(out, hitJSON{SearchHit: hit, URL: chunkURL(hit.MediaID, hit.ChunkNumber)})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"query":  f.Query,
+		"total":  total,
+		"limit":  f.Limit,
+		"offset": f.Offset,
+		"hits":   out,
+	})
+}
+
+func parseSearchFilter(r *http.Request) (models.SearchFilter, error) {
+	q := r.URL.Query()
+
+	f := models.SearchFilter{Query: strings.TrimSpace(q.Get("q"))}
+	if f.Query == "" {
+		return f, errors.New("missing q")
+	}
+	if len(f.Query) > maxSearchQuery {
+		return f, errors.New("q is too long")
+	}
+
+	var err error
+	if f.Limit, err = queryInt(r, "limit", 50); err != nil || f.Limit <= 0 || f.Limit > 200 {
+		return f, errors.New("invalid limit")
+	}
+	if f.Offset, err = queryInt(r, "offset", 0); err != nil || f.Offset < 0 {
+		return f, errors.New("invalid offset")
+	}
+
+	if v := q.Get("media"); v != "" {
+		for _, s := range strings.Split(v, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil || id <= 0 {
+				return f, errors.New("invalid media")
+			}
+			f.MediaIDs = append(f.MediaIDs, id)
+		}
+	}
+
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return f, errors.New("invalid from")
+		}
+		f.From = &t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return f, errors.New("invalid to")
+		}
+		t = t.Add(24 * time.Hour)
+		f.To = &t
+	}
+
+	return f, nil
+}
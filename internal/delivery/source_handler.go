@@ -0,0 +1,73 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+const trackProbeTimeout = 30 * time.Second
+
+type SourceHandler struct {
+	tracks ports.TrackProber
+	log    *logger.ZapLogger
+}
+
+func NewSourceHandler(tracks ports.TrackProber, log *logger.ZapLogger) *SourceHandler {
+	return &SourceHandler{
+		tracks: tracks,
+		log:    log,
+	}
+}
+
+// GET /api/sources/tracks?url= — аудиодорожки источника до старта сессии;
+// index отсюда передаётся в старт WS как audio.track
+func (h *SourceHandler) Tracks(w http.ResponseWriter, r *http.Request) {
+	srcURL := r.URL.Query().Get("url")
+	if srcURL == "" {
+		http.Error(w, "missing url", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), trackProbeTimeout)
+	defer cancel()
+
+	tracks, err := h.tracks.ProbeTracks(ctx, srcURL)
+	if err != nil {
+		status := http.StatusBadGateway
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			status = http.StatusGatewayTimeout
+		case errors.Is(err, ports.ErrSourceUnsupported):
+			status = http.StatusUnprocessableEntity
+		}
+
+		h.log.Log(logger.LogEntry{
+			Level:   "warn",
+			Message: "track probe failed",
+			Error:   err,
+			Fields:  map[string]any{"url": srcURL},
+		})
+		if code := ports.SourceErrorCode(err); code != "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"error": err.Error(),
+				"code":  code,
+			})
+			return
+		}
+		http.Error(w, "failed probe tracks: "+err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"tracks": tracks,
+	})
+}
@@ -0,0 +1,78 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type TranscriptHandler struct {
+	transcripts ports.TranscriptRepository
+	polisher    ports.TranscriptPolisher
+	log         *logger.ZapLogger
+}
+
+func NewTranscriptHandler(
+	transcripts ports.TranscriptRepository,
+	polisher ports.TranscriptPolisher,
+	log *logger.ZapLogger,
+) *TranscriptHandler {
+	return &TranscriptHandler{
+		transcripts: transcripts,
+		polisher:    polisher,
+		log:         log,
+	}
+}
+
+// GET /api/media/{id}/transcript?kind=edited
+func (h *TranscriptHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	kind := r.URL.Query().Get("kind")
+	if kind == "" {
+		kind = models.TranscriptEdited
+	}
+
+	t, err := h.transcripts.GetLatestTranscript(r.Context(), id, kind)
+	if err != nil {
+		http.Error(w, "failed get transcript: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if t == nil {
+		http.Error(w, "transcript not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(t)
+}
+
+// POST /api/media/{id}/polish — перезапустить редактуру вручную
+func (h *TranscriptHandler) Polish(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.polisher.Enqueue(id)
+
+	h.log.Log(logger.LogEntry{
+		Level:   "info",
+		Message: "transcript polish enqueued",
+		Fields:  map[string]any{"mediaID": id},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"status": "queued",
+	})
+}
@@ -0,0 +1,161 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/go-chi/chi/v5"
+)
+
+type UploadHandler struct {
+	uploader ports.MediaUploader
+	log      *logger.ZapLogger
+}
+
+func NewUploadHandler(uploader ports.MediaUploader, log *logger.ZapLogger) *UploadHandler {
+	return &UploadHandler{
+		uploader: uploader,
+		log:      log,
+	}
+}
+
+// POST /api/media/upload — multipart; поля идут ДО поля file:
+//
+//	roomID, user — куда слать прогресс и на кого писать расходы
+//	total        — полный размер файла; без него файл целиком в одном запросе
+//	uploadId     — продолжение загрузки, начатой раньше
+//	offset       — позиция части (= received из прошлого ответа)
+//
+// 200 — часть принята, 201 — файл принят и обработка запущена
+func (h *UploadHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "expected multipart/form-data", http.StatusBadRequest)
+		return
+	}
+
+	fields := map[string]string{}
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			http.Error(w, "missing file", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, "bad multipart: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if part.FormName() != "file" {
+			v, _ := io.ReadAll(io.LimitReader(part, 1024))
+			fields[part.FormName()] = string(v)
+			continue
+		}
+
+		total, err := formInt64(fields["total"])
+		if err != nil {
+			http.Error(w, "invalid total", http.StatusBadRequest)
+			return
+		}
+		offset, err := formInt64(fields["offset"])
+		if err != nil {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+
+		id := fields["uploadId"]
+		if id == "" {
+			filename := fields["filename"]
+			if filename == "" {
+				filename = part.FileName()
+			}
+
+			up, err := h.uploader.Begin(r.Context(), filename, total, fields["roomID"], fields["user"])
+			if err != nil {
+				h.respond(w, nil, err)
+				return
+			}
+			id = up.ID
+		}
+
+		up, err := h.uploader.Write(r.Context(), id, offset, part)
+		h.respond(w, up, err)
+		return
+	}
+}
+
+// GET /api/media/upload/{uploadId} — сколько принято: с этого offset продолжать
+func (h *UploadHandler) Status(w http.ResponseWriter, r *http.Request) {
+	up, err := h.uploader.Status(r.Context(), chi.URLParam(r, "uploadId"))
+	if err != nil {
+		h.respond(w, nil, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(up)
+}
+
+func (h *UploadHandler) respond(w http.ResponseWriter, up *models.Upload, err error) {
+	status := http.StatusOK
+
+	switch {
+	case err == nil:
+		if up.Started {
+			status = http.StatusCreated
+		}
+	case errors.Is(err, ports.ErrUploadNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ports.ErrUploadTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, ports.ErrUploadFormat):
+		status = http.StatusUnsupportedMediaType
+	case errors.Is(err, ports.ErrUploadOffset):
+		status = http.StatusConflict
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	if err != nil {
+		h.log.Log(logger.LogEntry{
+			Level:   "warn",
+			Message: "upload failed",
+			Error:   err,
+		})
+	}
+
+	resp := map[string]any{"status": "uploading"}
+	if up != nil {
+		resp["upload"] = up
+		if up.Started {
+			resp["status"] = "processing"
+			resp["mediaID"] = up.MediaID
+		}
+	}
+	if err != nil {
+		resp["status"] = "error"
+		resp["error"] = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func formInt64(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return 0, errors.New("invalid number")
+	}
+	return n, nil
+}
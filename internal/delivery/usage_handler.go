@@ -0,0 +1,102 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type UsageHandler struct {
+	usage ports.UsageRepository
+	log   *logger.ZapLogger
+}
+
+func NewUsageHandler(usage ports.UsageRepository, log *logger.ZapLogger) *UsageHandler {
+	return &UsageHandler{
+		usage: usage,
+		log:   log,
+	}
+}
+
+// GET /api/usage/media?from=2025-01-01&to=2025-02-01
+func (h *UsageHandler) ByMedia(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, "media", h.usage.CostByMedia)
+}
+
+// GET /api/usage/daily?from=...&to=...
+func (h *UsageHandler) ByDay(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, "day", h.usage.CostByDay)
+}
+
+// GET /api/usage/users?from=...&to=...
+func (h *UsageHandler) ByUser(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, "user", h.usage.CostByUser)
+}
+
+func (h *UsageHandler) serve(
+	w http.ResponseWriter,
+	r *http.Request,
+	group string,
+	fetch func(ctx context.Context, from, to time.Time) ([]models.CostSummary, error),
+) {
+	from, to, err := parseRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items, err := fetch(r.Context(), from, to)
+	if err != nil {
+		http.Error(w, "failed get usage: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.log.Log(logger.LogEntry{
+		Level:   "info",
+		Message: "usage fetched",
+		Fields: map[string]any{
+			"group": group,
+			"rows":  len(items),
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"group": group,
+		"from":  from,
+		"to":    to,
+		"items": items,
+	})
+}
+
+// parseRange — from/to в формате YYYY-MM-DD; по умолчанию последние 30 дней.
+// to включительно.
+func parseRange(r *http.Request) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	to := now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+	from := to.AddDate(0, 0, -30)
+
+	if v := r.URL.Query().Get("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return from, to, errors.New("invalid from")
+		}
+		from = t
+	}
+
+	if v := r.URL.Query().Get("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return from, to, errors.New("invalid to")
+		}
+		to = t.Add(24 * time.Hour)
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,157 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Vovarama1992/go-utils/logger"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+type WatchHandler struct {
+	watches ports.WatchManager
+	log     *logger.ZapLogger
+}
+
+func NewWatchHandler(watches ports.WatchManager, log *logger.ZapLogger) *WatchHandler {
+	return &WatchHandler{
+		watches: watches,
+		log:     log,
+	}
+}
+
+type watchReq struct {
+	URL         string `json:"url"` // канал, его /streams или /live, плейлист
+	RoomID      string `json:"roomID"`
+	User        string `json:"user"`
+	Enabled     *bool  `json:"enabled"` // по умолчанию true
+	IntervalSec int    `json:"intervalSec"`
+}
+
+func (req *watchReq) subscription() *models.WatchSubscription {
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	return &models.WatchSubscription{
+		URL:         req.URL,
+		RoomID:      req.RoomID,
+		User:        req.User,
+		Enabled:     enabled,
+		IntervalSec: req.IntervalSec,
+	}
+}
+
+// GET /api/watchers
+func (h *WatchHandler) List(w http.ResponseWriter, r *http.Request) {
+	watches, err := h.watches.List(r.Context())
+	if err != nil {
+		http.Error(w, "failed list watchers: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"watchers": watches,
+	})
+}
+
+// POST /api/watchers
+func (h *WatchHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req watchReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+
+	sub := req.subscription()
+	if err := h.watches.Create(r.Context(), sub); err != nil {
+		http.Error(w, err.Error(), watchErrStatus(err))
+		return
+	}
+
+	h.log.Log(logger.LogEntry{
+		Level:   "info",
+		Message: "watcher created",
+		Fields: map[string]any{
+			"watchID": sub.ID,
+			"url":     sub.URL,
+			"roomID":  sub.RoomID,
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(sub)
+}
+
+// PUT /api/watchers/{id} — подписка целиком
+func (h *WatchHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req watchReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+
+	sub := req.subscription()
+	sub.ID = id
+	if err := h.watches.Update(r.Context(), sub); err != nil {
+		http.Error(w, err.Error(), watchErrStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(sub)
+}
+
+// DELETE /api/watchers/{id} — идущая по подписке сессия останавливается
+func (h *WatchHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.watches.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), watchErrStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /api/watchers/{id}/check — опросить канал сейчас
+func (h *WatchHandler) Check(w http.ResponseWriter, r *http.Request) {
+	id, err := urlID(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sub, err := h.watches.Check(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), watchErrStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(sub)
+}
+
+func watchErrStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrWatchNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ports.ErrWatchInvalid):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
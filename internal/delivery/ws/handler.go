@@ -3,14 +3,24 @@ package ws
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/Vovarama1992/journalist/internal/models"
 	"github.com/Vovarama1992/journalist/internal/ports"
 )
 
 type startMsg struct {
 	URL     string `json:"url"`
 	MediaID int    `json:"mediaID"`
+	User    string `json:"user"`
+	// дорожка / канал: {"track": 1} или {"language": "en", "channel": "left"};
+	// язык STT он не меняет — для него поле language ниже
+	Audio models.AudioSelection `json:"audio"`
+	// язык распознавания (ru-RU, en-US): с дорожкой переводчика — её язык
+	Language string `json:"language"`
+	// пресет предобработки: speech, field, quiet, ...
+	Filter string `json:"filter"`
 }
 
 func WSHandler(
@@ -56,12 +66,38 @@ func WSHandler(
 			return
 		}
 
+		if req.Language != "" && !ports.ValidLanguage(req.Language) {
+			b, _ := json.Marshal(map[string]any{"status": "error", "error": "language must look like en-US"})
+			hub.SendToRoom(roomID, b)
+			return
+		}
+
 		// лог только факта
 		println("[WS] init url:", req.URL, "mediaID:", req.MediaID)
 		hub.SendToRoom(roomID, []byte(`{"status":"processing_started"}`))
 
+		// кто запустил — для учёта расходов
+		ctxWS = ports.WithUsageScope(ctxWS, ports.UsageScope{User: req.User})
+		ctxWS = ports.WithSessionOptions(ctxWS, ports.SessionOptions{
+			Language: req.Language,
+			Audio:    req.Audio,
+			Filter:   req.Filter,
+		})
+
 		go func() {
 			mediaObj, err := media.Process(ctxWS, req.URL, roomID, req.MediaID)
+			if errors.Is(err, ports.ErrTrackNotFound) || errors.Is(err, ports.ErrFilterUnknown) {
+				// клиенту нужна причина: выбрать другую дорожку / пресет
+				b, _ := json.Marshal(map[string]any{"status": "error", "error": err.Error()})
+				hub.SendToRoom(roomID, b)
+				return
+			}
+			if code := ports.SourceErrorCode(err); code != "" {
+				// источник недоступен: код — чтобы клиент показал, что делать (куки, другой URL)
+				b, _ := json.Marshal(map[string]any{"status": "error", "code": code, "error": err.Error()})
+				hub.SendToRoom(roomID, b)
+				return
+			}
 			if err != nil {
 				println("[WS] media error")
 				hub.SendToRoom(roomID, []byte(`{"status":"error"}`))
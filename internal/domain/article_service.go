@@ -0,0 +1,251 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+// сколько исходного текста максимум отдаём модели; длиннее — черновик
+// по началу и SourceTruncated, чтобы журналист сузил диапазон чанков
+const articleSourceChars = 60000
+
+const articleSystemPrompt = `Ты — редактор новостной ленты. По расшифровке выступления
+или трансляции напиши черновик новости.
+
+Верни СТРОГО JSON-объект:
+{
+  "headlines": ["вариант 1", "вариант 2", ...],
+  "lead": "лид — 1–2 предложения: кто, что, где, когда",
+  "body": "основной текст, абзацы через пустую строку",
+  "quotes": [{"speaker": "кто сказал", "text": "дословная цитата"}]
+}
+
+ПРАВИЛА:
+— Только факты из расшифровки. Ничего не выдумывай.
+— Цитаты — ДОСЛОВНО из текста. Если говорящий неизвестен, пиши "неизвестный спикер".
+— Имена, должности, числа — как в тексте.
+— Без оценок и домыслов.`
+
+type ArticleService struct {
+	repo        ports.MediaRepository
+	transcripts ports.TranscriptRepository
+	articles    ports.ArticleRepository
+	llm         ports.LLMService
+}
+
+func NewArticleService(
+	repo ports.MediaRepository,
+	transcripts ports.TranscriptRepository,
+	articles ports.ArticleRepository,
+	llm ports.LLMService,
+) *ArticleService {
+	return &ArticleService{
+		repo:        repo,
+		transcripts: transcripts,
+		articles:    articles,
+		llm:         llm,
+	}
+}
+
+type articleLLMOut struct {
+	Headlines []string              `json:"headlines"`
+	Lead      string                `json:"lead"`
+	Body      string                `json:"body"`
+	Quotes    []models.ArticleQuote `json:"quotes"`
+}
+
+func (s *ArticleService) Generate(
+	ctx context.Context,
+	mediaID, chunkFrom, chunkTo int,
+	params models.ArticleParams,
+) (*models.ArticleDraft, error) {
+
+	media, err := s.repo.GetMediaByID(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if media == nil {
+		return nil, ports.ErrMediaNotFound
+	}
+
+	draft := &models.ArticleDraft{
+		MediaID:   mediaID,
+		ChunkFrom: chunkFrom,
+		ChunkTo:   chunkTo,
+		Params:    params,
+	}
+
+	if err := s.fill(ctx, draft); err != nil {
+		return nil, err
+	}
+
+	return s.articles.InsertArticle(ctx, draft)
+}
+
+func (s *ArticleService) Regenerate(
+	ctx context.Context,
+	id int,
+	params *models.ArticleParams,
+) (*models.ArticleDraft, error) {
+
+	draft, err := s.articles.GetArticle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if draft == nil {
+		return nil, ports.ErrArticleNotFound
+	}
+
+	if params != nil {
+		draft.Params = *params
+	}
+
+	// правку журналиста не затираем: новая версия рядом с исходной
+	if draft.Edited {
+		parentID := draft.ID
+		next := &models.ArticleDraft{
+			MediaID:   draft.MediaID,
+			ChunkFrom: draft.ChunkFrom,
+			ChunkTo:   draft.ChunkTo,
+			Params:    draft.Params,
+			ParentID:  &parentID,
+		}
+		if err := s.fill(ctx, next); err != nil {
+			return nil, err
+		}
+		return s.articles.InsertArticle(ctx, next)
+	}
+
+	if err := s.fill(ctx, draft); err != nil {
+		return nil, err
+	}
+
+	if err := s.articles.UpdateArticle(ctx, draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// fill — исходный текст → LLM → поля черновика
+func (s *ArticleService) fill(ctx context.Context, draft *models.ArticleDraft) error {
+	source, err := s.sourceText(ctx, draft.MediaID, draft.ChunkFrom, draft.ChunkTo)
+	if err != nil {
+		return err
+	}
+
+	draft.SourceTruncated = len(source) > articleSourceChars
+	if draft.SourceTruncated {
+		log.Printf("[ARTICLE][TRUNCATE] media=%d chunks=%d..%d chars=%d limit=%d",
+			draft.MediaID, draft.ChunkFrom, draft.ChunkTo, len(source), articleSourceChars)
+		source = truncate(source, articleSourceChars)
+	}
+
+	ctx = ports.WithUsageScope(ctx, ports.UsageScope{MediaID: draft.MediaID})
+
+	out, err := s.llm.Complete(ctx, ports.CompletionRequest{
+		Operation: "article",
+		System:    articleSystemPrompt,
+		User:      articleUserPrompt(draft.Params, source),
+		MaxTokens: 4000,
+		JSON:      true,
+	})
+	if err != nil {
+		return err
+	}
+
+	var parsed articleLLMOut
+	if err := decodeLLMJSON(out, &parsed); err != nil {
+		return err
+	}
+
+	draft.Headlines = parsed.Headlines
+	draft.Lead = strings.TrimSpace(parsed.Lead)
+	draft.Body = strings.TrimSpace(parsed.Body)
+	draft.Quotes = parsed.Quotes
+
+	log.Printf("[ARTICLE][OK] media=%d chunks=%d..%d headlines=%d quotes=%d",
+		draft.MediaID, draft.ChunkFrom, draft.ChunkTo, len(draft.Headlines), len(draft.Quotes))
+	return nil
+}
+
+// sourceText — для всей media предпочитаем отредактированную версию,
+// для диапазона — текст чанков как есть. Без обрезки: лимит — в fill
+func (s *ArticleService) sourceText(ctx context.Context, mediaID, chunkFrom, chunkTo int) (string, error) {
+	if chunkFrom == 0 && chunkTo == 0 {
+		t, err := s.transcripts.GetLatestTranscript(ctx, mediaID, models.TranscriptEdited)
+		if err != nil {
+			return "", err
+		}
+		if t != nil && t.Status == models.TranscriptDone && t.Text != "" {
+			return t.Text, nil
+		}
+	}
+
+	chunks, err := s.repo.ListCompletedChunks(ctx, mediaID)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, c := range chunks {
+		if chunkFrom > 0 && c.ChunkNumber < chunkFrom {
+			continue
+		}
+		if chunkTo > 0 && c.ChunkNumber > chunkTo {
+			break
+		}
+		sb.WriteString(strings.TrimSpace(c.Text))
+		sb.WriteString(" ")
+	}
+
+	text := strings.TrimSpace(sb.String())
+	if text == "" {
+		return "", ports.ErrArticleNoText
+	}
+	return text, nil
+}
+
+func articleUserPrompt(p models.ArticleParams, source string) string {
+	var sb strings.Builder
+
+	headlines := p.Headlines
+	if headlines <= 0 {
+		headlines = 3
+	}
+	fmt.Fprintf(&sb, "Вариантов заголовка: %d\n", headlines)
+
+	if p.Language != "" {
+		fmt.Fprintf(&sb, "Язык текста: %s\n", p.Language)
+	}
+	if p.Tone != "" {
+		fmt.Fprintf(&sb, "Тон: %s\n", p.Tone)
+	}
+	switch p.Length {
+	case "short":
+		sb.WriteString("Объём тела: до 800 знаков\n")
+	case "long":
+		sb.WriteString("Объём тела: 3000–5000 знаков\n")
+	default:
+		sb.WriteString("Объём тела: 1500–2500 знаков\n")
+	}
+	if p.Focus != "" {
+		fmt.Fprintf(&sb, "Акцент: %s\n", p.Focus)
+	}
+
+	sb.WriteString("\nРасшифровка:\n")
+	sb.WriteString(source)
+	return sb.String()
+}
+
+// truncate — первые max байт по границе руны
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return strings.ToValidUTF8(s[:max], "")
+}
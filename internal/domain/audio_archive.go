@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/domain/stations"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+const recordRetryDelay = 3 * time.Second
+
+// AudioArchiveService — непрерывная запись live-сессии в MP3-сегменты
+// (storage + media_audio_segment) и чтение её одним потоком
+type AudioArchiveService struct {
+	repo    ports.AudioSegmentRepository
+	storage ports.ObjectStorage
+	src     *stations.SourceRegistry
+	rec     *stations.S2Record
+	dir     string
+}
+
+func NewAudioArchiveService(
+	repo ports.AudioSegmentRepository,
+	storage ports.ObjectStorage,
+	src *stations.SourceRegistry,
+	rec *stations.S2Record,
+) *AudioArchiveService {
+	return &AudioArchiveService{
+		repo:    repo,
+		storage: storage,
+		src:     src,
+		rec:     rec,
+		dir:     chunkDir(),
+	}
+}
+
+// Record — до отмены ctx; ffmpeg падает (протух URL, обрыв) → перезапуск
+// с новым URL, нумерация сегментов продолжается
+func (s *AudioArchiveService) Record(ctx context.Context, mediaID int, srcURL string, from time.Time) {
+	dir := filepath.Join(s.dir, fmt.Sprintf("media_%d", mediaID), "audio")
+
+	next := 0
+	if segs, err := s.repo.ListAudioSegments(ctx, mediaID); err == nil && len(segs) > 0 {
+		next = segs[len(segs)-1].Seq + 1
+	}
+
+	// последний сегмент закрывается уже после отмены ctx
+	storeCtx := context.WithoutCancel(ctx)
+	audio := ports.SessionOptionsFrom(ctx).Audio
+
+	for ctx.Err() == nil {
+		audioURL, err := s.src.Resolve(ctx, srcURL)
+		if err != nil {
+			log.Printf("[REC][S1][FAIL] media=%d err=%v", mediaID, err)
+		} else {
+			offset := time.Since(from).Milliseconds()
+
+			err = s.rec.Run(ctx, audioURL, audio, dir, next, func(seq int, path string) {
+				next = seq + 1
+				offset += int64(s.store(storeCtx, mediaID, seq, offset, path))
+			})
+			if errors.Is(err, stations.ErrStreamRejected) {
+				s.src.Invalidate(srcURL)
+			}
+			if err != nil {
+				log.Printf("[REC][FAIL] media=%d err=%v", mediaID, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(recordRetryDelay):
+		}
+	}
+
+	log.Printf("[REC][STOP] media=%d", mediaID)
+}
+
+// store — сегмент в storage и БД; возвращает длительность (для offset следующего)
+func (s *AudioArchiveService) store(ctx context.Context, mediaID, seq int, offsetMs int64, path string) int {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Printf("[REC][SEG][FAIL] media=%d seq=%d err=%v", mediaID, seq, err)
+		return 0
+	}
+	defer os.Remove(path)
+	defer f.Close()
+
+	st, err := f.Stat()
+	if err != nil || st.Size() == 0 {
+		return 0
+	}
+
+	seg := &models.AudioSegment{
+		MediaID:    mediaID,
+		Seq:        seq,
+		OffsetMs:   offsetMs,
+		DurationMs: int(st.Size() / stations.RecordBytesPerMs),
+		Bytes:      st.Size(),
+	}
+
+	key := fmt.Sprintf("media/%d/audio/%06d.mp3", mediaID, seq)
+	seg.StorageURL, err = s.storage.Put(ctx, key, f, st.Size(), "audio/mpeg")
+	if err != nil {
+		log.Printf("[REC][SEG][FAIL] media=%d seq=%d err=%v", mediaID, seq, err)
+		return seg.DurationMs
+	}
+
+	if err := s.repo.InsertAudioSegment(ctx, seg); err != nil {
+		log.Printf("[REC][SEG][DB][FAIL] media=%d seq=%d err=%v", mediaID, seq, err)
+		return seg.DurationMs
+	}
+
+	log.Printf("[REC][SEG] media=%d seq=%d offset=%dms dur=%dms", mediaID, seq, offsetMs, seg.DurationMs)
+	return seg.DurationMs
+}
+
+func (s *AudioArchiveService) Open(ctx context.Context, mediaID int) (io.ReadSeekCloser, []models.AudioSegment, error) {
+	segs, err := s.repo.ListAudioSegments(ctx, mediaID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newSegmentReader(ctx, s.storage, segs), segs, nil
+}
+
+// ByteAt — CBR: внутри сегмента байт = мс × RecordBytesPerMs;
+// момент в разрыве записи → начало следующего сегмента
+func (s *AudioArchiveService) ByteAt(segs []models.AudioSegment, offsetMs int64) int64 {
+	var pos int64
+	for _, seg := range segs {
+		if offsetMs < seg.OffsetMs {
+			return pos
+		}
+		if offsetMs < seg.OffsetMs+int64(seg.DurationMs) {
+			return pos + min((offsetMs-seg.OffsetMs)*stations.RecordBytesPerMs, seg.Bytes)
+		}
+		pos += seg.Bytes
+	}
+	return pos
+}
+
+// ========================================================================
+// segmentReader — io.ReadSeeker поверх сегментов в storage (для http.ServeContent)
+// ========================================================================
+type segmentReader struct {
+	ctx     context.Context
+	storage ports.ObjectStorage
+	segs    []models.AudioSegment
+	starts  []int64 // позиция начала каждого сегмента в общем потоке
+	size    int64
+
+	pos    int64
+	cur    io.ReadCloser
+	curIdx int
+	curPos int64 // позиция, на которой стоит cur
+}
+
+func newSegmentReader(ctx context.Context, storage ports.ObjectStorage, segs []models.AudioSegment) *segmentReader {
+	r := &segmentReader{
+		ctx:     ctx,
+		storage: storage,
+		segs:    segs,
+		starts:  make([]int64, len(segs)),
+		curIdx:  -1,
+	}
+	for i, seg := range segs {
+		r.starts[i] = r.size
+		r.size += seg.Bytes
+	}
+	return r
+}
+
+func (r *segmentReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	r.pos = offset
+	return offset, nil
+}
+
+func (r *segmentReader) Read(p []byte) (int, error) {
+	if r.pos >= r.size {
+		return 0, io.EOF
+	}
+
+	idx := sort.Search(len(r.starts), func(i int) bool { return r.starts[i] > r.pos }) - 1
+	end := r.starts[idx] + r.segs[idx].Bytes
+
+	if r.cur == nil || r.curIdx != idx || r.curPos != r.pos {
+		if err := r.open(idx); err != nil {
+			return 0, err
+		}
+	}
+
+	if rest := end - r.pos; int64(len(p)) > rest {
+		p = p[:rest]
+	}
+
+	n, err := r.cur.Read(p)
+	r.pos += int64(n)
+	r.curPos += int64(n)
+
+	if err == io.EOF {
+		if r.pos < end {
+			return n, io.ErrUnexpectedEOF
+		}
+		err = nil
+	}
+	return n, err
+}
+
+// open — объект сегмента с нужного места (storage без Range: пропускаем начало)
+func (r *segmentReader) open(idx int) error {
+	r.Close()
+
+	rc, err := r.storage.Get(r.ctx, r.segs[idx].StorageURL)
+	if err != nil {
+		return err
+	}
+
+	skip := r.pos - r.starts[idx]
+	if _, err := io.CopyN(io.Discard, rc, skip); err != nil {
+		rc.Close()
+		return fmt.Errorf("seek segment %d: %w", r.segs[idx].Seq, err)
+	}
+
+	r.cur, r.curIdx, r.curPos = rc, idx, r.pos
+	return nil
+}
+
+func (r *segmentReader) Close() error {
+	if r.cur == nil {
+		return nil
+	}
+	err := r.cur.Close()
+	r.cur, r.curIdx = nil, -1
+	return err
+}
@@ -0,0 +1,270 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/domain/stations"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+const cookieCheckTO = 90 * time.Second
+
+// хосты, которым нужны куки другого домена
+var cookieHostAliases = map[string]string{
+	"youtu.be": "youtube.com",
+}
+
+// CookieJarService — cookies.txt по доменам: хранятся в БД, активные
+// выкладываются файлами в dir; S1 выбирает файл по хосту страницы
+type CookieJarService struct {
+	repo     ports.CookieJarRepository
+	dir      string
+	fallback string // YTDLP_COOKIES_FILE: если для домена jar нет
+
+	mu    sync.RWMutex
+	files map[string]string // домен → путь к активному jar
+}
+
+func NewCookieJarService(repo ports.CookieJarRepository, dir, fallback string) *CookieJarService {
+	return &CookieJarService{
+		repo:     repo,
+		dir:      dir,
+		fallback: fallback,
+		files:    make(map[string]string),
+	}
+}
+
+// CookieFile — stations.CookieSource: самый длинный совпавший домен
+func (s *CookieJarService) CookieFile(pageURL string) string {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return s.fallback
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if alias, ok := cookieHostAliases[host]; ok {
+		host = alias
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	best, file := "", s.fallback
+	for domain, path := range s.files {
+		if hostMatches(host, domain) && len(domain) > len(best) {
+			best, file = domain, path
+		}
+	}
+	return file
+}
+
+// Load — выложить активные jar на диск; при старте и после каждой правки
+func (s *CookieJarService) Load(ctx context.Context) error {
+	jars, err := s.repo.ListJars(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("cookie dir: %w", err)
+	}
+
+	files := make(map[string]string)
+	for _, j := range jars {
+		if !j.Active {
+			continue
+		}
+		path, err := s.writeJar(&j)
+		if err != nil {
+			return err
+		}
+		files[j.Domain] = path
+	}
+
+	s.mu.Lock()
+	s.files = files
+	s.mu.Unlock()
+
+	log.Printf("[COOKIES][LOAD] active=%d", len(files))
+	return nil
+}
+
+func (s *CookieJarService) Upload(ctx context.Context, domain, name, content, testURL string) (*models.CookieJar, error) {
+	domain = normalizeCookieDomain(domain)
+	if domain == "" {
+		return nil, fmt.Errorf("%w: empty domain", ports.ErrCookieJarInvalid)
+	}
+	if err := validateCookies(domain, content); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(testURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("%w: testURL is required", ports.ErrCookieJarInvalid)
+	}
+	host := strings.ToLower(u.Hostname())
+	if alias, ok := cookieHostAliases[host]; ok {
+		host = alias
+	}
+	if !hostMatches(host, domain) {
+		return nil, fmt.Errorf("%w: testURL host %s is not %s", ports.ErrCookieJarInvalid, host, domain)
+	}
+
+	jar := &models.CookieJar{Domain: domain, Name: name, Content: content}
+	if err := s.repo.InsertJar(ctx, jar); err != nil {
+		return nil, err
+	}
+
+	if err := s.check(ctx, jar, testURL); err != nil {
+		return jar, err
+	}
+
+	if err := s.repo.ActivateJar(ctx, jar.ID); err != nil {
+		return nil, err
+	}
+	jar.Active = true
+
+	log.Printf("[COOKIES][ROTATE] domain=%s jar=%d", domain, jar.ID)
+	return jar, s.Load(ctx)
+}
+
+// Activate — вернуть прежний jar (откат ротации); только проверенный
+func (s *CookieJarService) Activate(ctx context.Context, id int) (*models.CookieJar, error) {
+	jar, err := s.repo.GetJar(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if jar == nil {
+		return nil, ports.ErrCookieJarNotFound
+	}
+	if jar.Status != models.CookieJarOK {
+		return nil, fmt.Errorf("%w: jar status is %s", ports.ErrCookieJarInvalid, jar.Status)
+	}
+
+	if err := s.repo.ActivateJar(ctx, id); err != nil {
+		return nil, err
+	}
+	jar.Active = true
+
+	return jar, s.Load(ctx)
+}
+
+func (s *CookieJarService) Delete(ctx context.Context, id int) error {
+	jar, err := s.repo.GetJar(ctx, id)
+	if err != nil {
+		return err
+	}
+	if jar == nil {
+		return ports.ErrCookieJarNotFound
+	}
+
+	if err := s.repo.DeleteJar(ctx, id); err != nil {
+		return err
+	}
+	_ = os.Remove(s.jarPath(jar))
+
+	return s.Load(ctx)
+}
+
+func (s *CookieJarService) List(ctx context.Context) ([]models.CookieJar, error) {
+	return s.repo.ListJars(ctx)
+}
+
+// check — test resolve с этим jar; результат пишется в jar
+func (s *CookieJarService) check(ctx context.Context, jar *models.CookieJar, testURL string) error {
+	path, err := s.writeJar(jar)
+	if err != nil {
+		return err
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, cookieCheckTO)
+	_, resolveErr := stations.ResolveWithCookies(checkCtx, testURL, path)
+	cancel()
+
+	jar.Status, jar.LastError = models.CookieJarOK, ""
+	if resolveErr != nil {
+		jar.Status, jar.LastError = models.CookieJarFailed, resolveErr.Error()
+		_ = os.Remove(path)
+	}
+
+	if err := s.repo.SetJarCheck(ctx, jar.ID, jar.Status, jar.LastError); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	jar.CheckedAt = &now
+
+	if resolveErr != nil {
+		log.Printf("[COOKIES][CHECK][FAIL] domain=%s jar=%d err=%v", jar.Domain, jar.ID, resolveErr)
+		return fmt.Errorf("%w: %v", ports.ErrCookieJarCheck, resolveErr)
+	}
+	return nil
+}
+
+func (s *CookieJarService) writeJar(jar *models.CookieJar) (string, error) {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return "", fmt.Errorf("cookie dir: %w", err)
+	}
+
+	// yt-dlp дописывает обновлённые куки в файл — пусть пишет в свою копию
+	path := s.jarPath(jar)
+	if err := os.WriteFile(path, []byte(jar.Content), 0600); err != nil {
+		return "", fmt.Errorf("write cookie jar: %w", err)
+	}
+	return path, nil
+}
+
+func (s *CookieJarService) jarPath(jar *models.CookieJar) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s_%d.txt", jar.Domain, jar.ID))
+}
+
+func normalizeCookieDomain(d string) string {
+	d = strings.ToLower(strings.TrimSpace(d))
+	d = strings.TrimPrefix(d, ".")
+	d = strings.TrimPrefix(d, "www.")
+	if strings.ContainsAny(d, "/: \t") {
+		return ""
+	}
+	return d
+}
+
+// hostMatches — host равен домену или его поддомен
+func hostMatches(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// validateCookies — формат Netscape cookies.txt и хотя бы одна кука домена
+func validateCookies(domain, content string) error {
+	found := false
+
+	for _, ln := range strings.Split(content, "\n") {
+		ln = strings.TrimRight(ln, "\r")
+		ln = strings.TrimPrefix(ln, "#HttpOnly_")
+		if ln == "" || strings.HasPrefix(ln, "#") {
+			continue
+		}
+
+		fields := strings.Split(ln, "\t")
+		if len(fields) != 7 {
+			return fmt.Errorf("%w: not a Netscape cookies.txt line: %q", ports.ErrCookieJarInvalid, truncate(ln, 60))
+		}
+
+		if hostMatches(strings.TrimPrefix(strings.ToLower(fields[0]), "."), domain) {
+			found = true
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("%w: no cookies for %s", ports.ErrCookieJarInvalid, domain)
+	}
+	return nil
+}
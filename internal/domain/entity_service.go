@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+// ========================================================================
+// RULE-BASED
+// ========================================================================
+
+var (
+	reEntityDate = regexp.MustCompile(
+		`(?i)\b\d{1,2}\.\d{1,2}\.\d{2,4}\b` +
+			`|\d{1,2}\s+(?:января|февраля|марта|апреля|мая|июня|июля|августа|сентября|октября|ноября|декабря)(?:\s+\d{4}(?:\s+года)?)?` +
+			`|\d{4}\s+год(?:а|у)?`,
+	)
+	reEntityNumber = regexp.MustCompile(
+		`\d+(?:[ \x{00A0}]\d{3})*(?:[.,]\d+)?(?:\s?(?:%|процент[а-я]*|млн|млрд|трлн|тыс\.?))?`,
+	)
+	reEntityName = regexp.MustCompile(
+		`\p{Lu}[\p{Ll}\-]+(?:\s+\p{Lu}[\p{Ll}\-]+)*`,
+	)
+)
+
+// RuleEntityExtractor — без LLM: даты, числа и имена собственные
+// (слова с заглавной не в начале предложения). Тип имени не определяет.
+type RuleEntityExtractor struct{}
+
+func NewRuleEntityExtractor() *RuleEntityExtractor { return &RuleEntityExtractor{} }
+
+func (e *RuleEntityExtractor) Extract(ctx context.Context, text string) ([]models.Entity, error) {
+	var out []models.Entity
+	taken := make([]bool, len(text))
+
+	add := func(kind string, loc []int) {
+		for i := loc[0]; i < loc[1]; i++ {
+			if taken[i] {
+				return
+			}
+		}
+		for i := loc[0]; i < loc[1]; i++ {
+			taken[i] = true
+		}
+		value := strings.TrimSpace(text[loc[0]:loc[1]])
+		out = append(out, models.Entity{
+			Kind:       kind,
+			Value:      value,
+			Normalized: models.NormalizeEntity(value),
+		})
+	}
+
+	for _, loc := range reEntityDate.FindAllStringIndex(text, -1) {
+		add(models.EntityDate, loc)
+	}
+	for _, loc := range reEntityNumber.FindAllStringIndex(text, -1) {
+		add(models.EntityNumber, loc)
+	}
+	for _, loc := range reEntityName.FindAllStringIndex(text, -1) {
+		if sentenceStart(text, loc[0]) && !strings.ContainsAny(text[loc[0]:loc[1]], "  ") {
+			continue // одно слово с заглавной в начале предложения — не имя
+		}
+		add(models.EntityName, loc)
+	}
+
+	return dedupEntities(out), nil
+}
+
+func sentenceStart(text string, pos int) bool {
+	for pos > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:pos])
+		pos -= size
+		if unicode.IsSpace(r) || r == '«' || r == '"' || r == '(' {
+			continue
+		}
+		return r == '.' || r == '!' || r == '?' || r == '…' || r == '—'
+	}
+	return true
+}
+
+// ========================================================================
+// LLM-BASED
+// ========================================================================
+
+const entitySystemPrompt = `Извлеки именованные сущности из фрагмента расшифровки.
+Верни СТРОГО JSON-объект:
+{"entities": [{"kind": "person|organization|place|number|date", "value": "как в тексте", "normalized": "начальная форма"}]}
+
+— person: люди (ФИО, фамилии, с должностью не смешивай).
+— organization: ведомства, компании, партии, СМИ.
+— place: страны, города, районы, улицы, объекты.
+— number: суммы, проценты, количества — с единицами.
+— date: даты и периоды.
+normalized — именительный падеж, полная форма ("Мосгордума", "Сергей Собянин").
+Ничего не выдумывай. Нет сущностей — {"entities": []}.`
+
+type LLMEntityExtractor struct {
+	llm ports.LLMService
+}
+
+func NewLLMEntityExtractor(llm ports.LLMService) *LLMEntityExtractor {
+	return &LLMEntityExtractor{llm: llm}
+}
+
+func (e *LLMEntityExtractor) Extract(ctx context.Context, text string) ([]models.Entity, error) {
+	out, err := e.llm.Complete(ctx, ports.CompletionRequest{
+		Operation: "entities",
+		System:    entitySystemPrompt,
+		User:      text,
+		MaxTokens: 1500,
+		JSON:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed struct {
+		Entities []struct {
+			Kind       string `json:"kind"`
+			Value      string `json:"value"`
+			Normalized string `json:"normalized"`
+		} `json:"entities"`
+	}
+	if err := decodeLLMJSON(out, &parsed); err != nil {
+		return nil, err
+	}
+
+	var entities []models.Entity
+	for _, p := range parsed.Entities {
+		switch p.Kind {
+		case models.EntityPerson, models.EntityOrganization, models.EntityPlace,
+			models.EntityNumber, models.EntityDate:
+		default:
+			continue
+		}
+		value := strings.TrimSpace(p.Value)
+		if value == "" {
+			continue
+		}
+		norm := p.Normalized
+		if strings.TrimSpace(norm) == "" {
+			norm = value
+		}
+		entities = append(entities, models.Entity{
+			Kind:       p.Kind,
+			Value:      value,
+			Normalized: models.NormalizeEntity(norm),
+		})
+	}
+
+	return dedupEntities(entities), nil
+}
+
+func dedupEntities(in []models.Entity) []models.Entity {
+	seen := make(map[string]bool, len(in))
+	out := in[:0]
+	for _, e := range in {
+		key := e.Kind + "|" + e.Normalized
+		if e.Normalized == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, e)
+	}
+	return out
+}
+
+// ========================================================================
+// INDEXER
+// ========================================================================
+
+type EntityService struct {
+	repo      ports.MediaRepository
+	entities  ports.EntityRepository
+	extractor ports.EntityExtractor
+}
+
+func NewEntityService(
+	repo ports.MediaRepository,
+	entities ports.EntityRepository,
+	extractor ports.EntityExtractor,
+) *EntityService {
+	return &EntityService{
+		repo:      repo,
+		entities:  entities,
+		extractor: extractor,
+	}
+}
+
+// IndexChunk — в фоне, не задерживает выдачу чанка на фронт
+func (s *EntityService) IndexChunk(chunk models.MediaChunk) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		if err := s.indexChunk(ctx, &chunk); err != nil {
+			log.Printf("[ENTITY][FAIL] media=%d chunk=%d err=%v", chunk.MediaID, chunk.ChunkNumber, err)
+		}
+	}()
+}
+
+// IndexMedia — синхронная (пере)индексация всех готовых чанков media
+func (s *EntityService) IndexMedia(ctx context.Context, mediaID int) (int, error) {
+	chunks, err := s.repo.ListCompletedChunks(ctx, mediaID)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range chunks {
+		if err := s.indexChunk(ctx, &chunks[i]); err != nil {
+			return i, fmt.Errorf("chunk %d: %w", chunks[i].ChunkNumber, err)
+		}
+	}
+	return len(chunks), nil
+}
+
+func (s *EntityService) indexChunk(ctx context.Context, chunk *models.MediaChunk) error {
+	if strings.TrimSpace(chunk.Text) == "" {
+		return nil
+	}
+
+	ctx = ports.WithUsageScope(ctx, ports.UsageScope{
+		MediaID:     chunk.MediaID,
+		ChunkNumber: chunk.ChunkNumber,
+	})
+
+	entities, err := s.extractor.Extract(ctx, chunk.Text)
+	if err != nil {
+		return err
+	}
+
+	if err := s.entities.ReplaceChunkEntities(ctx, chunk, entities); err != nil {
+		return err
+	}
+
+	log.Printf("[ENTITY][OK] media=%d chunk=%d entities=%d", chunk.MediaID, chunk.ChunkNumber, len(entities))
+	return nil
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+// GlossaryHints — фразы-подсказки для STT: правильные написания терминов
+func GlossaryHints(terms []models.GlossaryTerm) []string {
+	hints := make([]string, 0, len(terms))
+	for _, t := range terms {
+		if sp := glossarySpelling(t); sp != "" {
+			hints = append(hints, sp)
+		}
+	}
+	return hints
+}
+
+// ApplyGlossary — детерминированная пост-коррекция текста чанка:
+// каждый alias (и сам term) заменяется на spelling. Регистр не важен,
+// совпадение только по целым словам. Длинные alias — первыми.
+func ApplyGlossary(text string, terms []models.GlossaryTerm) string {
+	type repl struct {
+		from string
+		to   string
+	}
+
+	var repls []repl
+	for _, t := range terms {
+		to := glossarySpelling(t)
+		if to == "" {
+			continue
+		}
+		for _, from := range append([]string{t.Term}, t.Aliases...) {
+			from = strings.TrimSpace(from)
+			if from == "" || from == to {
+				continue
+			}
+			repls = append(repls, repl{from: from, to: to})
+		}
+	}
+
+	sort.SliceStable(repls, func(i, j int) bool {
+		return utf8.RuneCountInString(repls[i].from) > utf8.RuneCountInString(repls[j].from)
+	})
+
+	for _, r := range repls {
+		text = replaceWord(text, r.from, r.to)
+	}
+	return text
+}
+
+func glossarySpelling(t models.GlossaryTerm) string {
+	if sp := strings.TrimSpace(t.Spelling); sp != "" {
+		return sp
+	}
+	return strings.TrimSpace(t.Term)
+}
+
+// replaceWord — регистронезависимая замена только целых слов.
+// \b в regexp Go понимает лишь ASCII, поэтому границы проверяем руками.
+func replaceWord(text, from, to string) string {
+	re := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(from))
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		start, end := loc[0], loc[1]
+
+		if start > 0 {
+			r, _ := utf8.DecodeLastRuneInString(text[:start])
+			if isWordRune(r) {
+				continue
+			}
+		}
+		if end < len(text) {
+			r, _ := utf8.DecodeRuneInString(text[end:])
+			if isWordRune(r) {
+				continue
+			}
+		}
+
+		sb.WriteString(text[last:start])
+		sb.WriteString(to)
+		last = end
+	}
+
+	if last == 0 {
+		return text
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -8,12 +8,12 @@ import (
 
 type gptService struct {
 	client interface {
-		Generate(ctx context.Context, previous, nextRaw string) (string, error)
+		Generate(ctx context.Context, previous, nextRaw string, opts ports.ChunkOptions) (string, error)
 	}
 }
 
 func NewGPTService(client interface {
-	Generate(ctx context.Context, previous, nextRaw string) (string, error)
+	Generate(ctx context.Context, previous, nextRaw string, opts ports.ChunkOptions) (string, error)
 }) ports.GPTService {
 	return &gptService{client: client}
 }
@@ -22,6 +22,8 @@ func (s *gptService) ProcessChunk(
 	ctx context.Context,
 	lastChunk string,
 	newChunk string,
+	opts ports.ChunkOptions,
 ) (string, error) {
-	return s.client.Generate(ctx, lastChunk, newChunk)
+	// глоссарий и указания сессии — в промпт клиента
+	return s.client.Generate(ctx, lastChunk, newChunk, opts)
 }
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// decodeLLMJSON — модели иногда оборачивают JSON в ```json ... ``` или
+// добавляют текст вокруг; берём первый объект { ... } целиком.
+func decodeLLMJSON(out string, v any) error {
+	s := strings.TrimSpace(out)
+
+	start := strings.Index(s, "{")
+	end := strings.LastIndex(s, "}")
+	if start < 0 || end < start {
+		return fmt.Errorf("llm returned no json object: %.120q", s)
+	}
+
+	if err := json.Unmarshal([]byte(s[start:end+1]), v); err != nil {
+		return fmt.Errorf("llm json: %w", err)
+	}
+	return nil
+}
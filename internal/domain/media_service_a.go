@@ -1,13 +1,16 @@
 package domain
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Vovarama1992/journalist/internal/domain/stations"
@@ -16,43 +19,142 @@ import (
 )
 
 type MediaService struct {
-	repo ports.MediaRepository
-
-	s1 *stations.S1ResolveURL
-	s2 *stations.S2GrabPCM
-	s3 *stations.S3PCMtoWAV
-	s4 *stations.S4WAVtoText
-	s5 *stations.S5GPT
-
-	logger *log.Logger
+	repo     ports.MediaRepository
+	glossary ports.GlossaryRepository
+	storage  ports.ObjectStorage
+
+	// источник → вход ffmpeg; yt-dlp (S1) только для страниц
+	src *stations.SourceRegistry
+	s2  *stations.S2GrabPCM
+	s3  *stations.S3PCMtoWAV
+	s4  *stations.S4WAVtoText
+	s5  *stations.S5GPT
+
+	// вызывается, когда ingest media остановлен (WS закрыт, эфир кончился)
+	onFinished func(mediaID int)
+	// вызывается, когда сессия завершилась сама (models.MediaEnd*), а не отменой ctx
+	onEnded func(mediaID int, reason string)
+	// вызывается для каждого успешно завершённого чанка (индексация и т.п.)
+	onChunkDone func(chunk models.MediaChunk)
+	// вызывается при старте live-сессии (запись аудио); живёт до отмены ctx
+	onLiveStart func(ctx context.Context, mediaID int, srcURL string, from time.Time)
+
+	// рабочие PCM чанков; после архивации WAV в storage удаляются
+	chunkDir string
+
+	events chan ports.ChunkEvent
+}
 
-	mu      sync.Mutex
-	mediaID int
-	roomID  string
-	events  chan ports.ChunkEvent
+// mediaSession — один запуск Process: WS-эфир, запись, загруженный файл.
+// Сессий может быть несколько одновременно — общего состояния в MediaService нет
+type mediaSession struct {
+	*MediaService
+
+	mediaID   int
+	mediaFrom time.Time // начало media: от него считаются offset_ms чанков
+	roomID    string
+	user      string
+	opts      ports.SessionOptions
+	logger    *log.Logger
+
+	// конец эфира: live-сессия останавливает себя сама
+	live      bool // источник при старте сообщил, что идёт эфир
+	checkAll  bool // статус источника дешёвый (m3u8) — спрашиваем каждый тик
+	stop      context.CancelFunc
+	endOnce   sync.Once
+	endReason string
+	fails     atomic.Int32 // S1/S2 подряд без звука
+
+	// сбои источника: пауза до следующего окна и последний код, ушедший клиенту
+	retryAt atomic.Int64 // unix nano
+	errMu   sync.Mutex
+	errCode string
 }
 
 func NewMediaService(
 	repo ports.MediaRepository,
-	s1 *stations.S1ResolveURL,
+	glossary ports.GlossaryRepository,
+	storage ports.ObjectStorage,
+	src *stations.SourceRegistry,
 	s2 *stations.S2GrabPCM,
 	s3 *stations.S3PCMtoWAV,
 	s4 *stations.S4WAVtoText,
 	gpt ports.GPTService,
 ) *MediaService {
 	return &MediaService{
-		repo:   repo,
-		s1:     s1,
-		s2:     s2,
-		s3:     s3,
-		s4:     s4,
-		s5:     stations.NewS5GPT(gpt),
-		events: make(chan ports.ChunkEvent, 100),
+		repo:     repo,
+		glossary: glossary,
+		storage:  storage,
+		src:      src,
+		s2:       s2,
+		s3:       s3,
+		s4:       s4,
+		s5:       stations.NewS5GPT(gpt),
+		chunkDir: chunkDir(),
+		events:   make(chan ports.ChunkEvent, 100),
 	}
 }
 
+const (
+	// сколько ждать ffprobe дорожек на старте сессии
+	trackProbeTO = 20 * time.Second
+
+	// конец эфира: статус источника — раз в минуту (тик 10 с) или после
+	// endCheckFails сбоев подряд; endMaxFails сбоев подряд — конец без вопросов
+	liveCheckTicks = 6
+	endCheckFails  = 3
+	endMaxFails    = 12
+
+	// VOD: сетевой сбой / лимит повторяется до vodRetries раз подряд
+	vodRetries    = 3
+	vodRetryPause = 5 * time.Second
+
+	// номер чанка в момент захвата: повторы, пока окно ещё не ушло далеко
+	chunkSeqAttempts   = 3
+	chunkSeqRetryPause = 300 * time.Millisecond
+)
+
+func chunkDir() string {
+	if dir := os.Getenv("CHUNK_AUDIO_DIR"); dir != "" {
+		return dir
+	}
+	return "/tmp/journalist"
+}
+
 func (m *MediaService) Events() <-chan ports.ChunkEvent { return m.events }
 
+// OnFinished — ingest остановлен по любой причине, в т.ч. закрытие WS;
+// пост-обработку законченной media (редактура) вешать на OnEnded
+func (m *MediaService) OnFinished(fn func(mediaID int)) { m.onFinished = fn }
+
+// OnChunkDone — хук для готового чанка; не должен блокировать
+func (m *MediaService) OnChunkDone(fn func(chunk models.MediaChunk)) { m.onChunkDone = fn }
+
+// OnEnded — хук для сессии, завершённой источником (эфир кончился,
+// запись обработана); после него всё равно зовётся OnFinished
+func (m *MediaService) OnEnded(fn func(mediaID int, reason string)) { m.onEnded = fn }
+
+// OnLiveStart — хук для live-сессии; запускается в своей горутине
+func (m *MediaService) OnLiveStart(fn func(ctx context.Context, mediaID int, srcURL string, from time.Time)) {
+	m.onLiveStart = fn
+}
+
+// Notify — ports.Notifier: уведомление событием в room
+func (m *MediaService) Notify(ctx context.Context, n models.Notification) error {
+	select {
+	case m.events <- ports.ChunkEvent{
+		Type:    ports.EventNotice,
+		RoomID:  n.RoomID,
+		MediaID: n.MediaID,
+		Text:    n.Text,
+		Notice:  &n,
+	}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // ========================================================================
 // PROCESS
 // ========================================================================
@@ -62,8 +164,17 @@ func (m *MediaService) Process(
 	roomID string,
 	mediaID int,
 ) (*models.Media, error) {
+	sess := &mediaSession{MediaService: m, roomID: roomID}
+	return sess.start(ctx, srcURL, mediaID)
+}
 
-	m.roomID = roomID
+func (m *mediaSession) start(ctx context.Context, srcURL string, mediaID int) (*models.Media, error) {
+	// user для учёта расходов: из контекста WS, иначе room
+	m.user = ports.UsageScopeFrom(ctx).User
+	if m.user == "" {
+		m.user = m.roomID
+	}
+	m.opts = ports.SessionOptionsFrom(ctx)
 
 	var media *models.Media
 	var err error
@@ -88,6 +199,7 @@ func (m *MediaService) Process(
 		}
 		m.mediaID = media.ID
 	}
+	m.mediaFrom = media.CreatedAt
 
 	// ---------- LOGGER ----------
 	logDir := "/app/logs"
@@ -111,80 +223,583 @@ func (m *MediaService) Process(
 
 	m.logger.Printf("[START] media=%d room=%s", m.mediaID, m.roomID)
 
+	// метаданные: от is_live зависит режим — живой эфир или запись целиком
+	if meta := m.fetchMeta(ctx, srcURL); meta != nil {
+		media.MediaMeta = *meta
+	}
+	m.live = media.IsLive
+	// законченный m3u8 ffmpeg читает с начала — ENDLIST нужно увидеть сразу
+	m.checkAll = m.src.Detect(ctx, srcURL) == stations.SourceHLS
+
+	// дорожки: отчёт клиенту; явный выбор, которого нет в источнике, — ошибка старта
+	audio, err := m.selectAudio(ctx, srcURL)
+	if err != nil {
+		m.logger.Printf("[TRACKS][FAIL] media=%d err=%v", m.mediaID, err)
+		return nil, err
+	}
+	m.opts.Audio = audio
+	// запись эфира (onLiveStart) берёт ту же дорожку из context
+	ctx = ports.WithSessionOptions(ctx, m.opts)
+
+	if m.opts.Filter != "" {
+		if err := m.SetFilter(ctx, m.mediaID, m.opts.Filter); err != nil {
+			return nil, err
+		}
+		media.AudioFilter = m.opts.Filter
+	}
+
+	// сессию может завершить и сам источник — свой cancel поверх ctx вызывающего
+	ctx, m.stop = context.WithCancel(ctx)
+
+	if !media.IsLive && media.DurationSec > 0 {
+		go m.ingestVOD(ctx, srcURL, media.DurationSec)
+		return media, nil
+	}
+
+	if m.onLiveStart != nil {
+		go m.onLiveStart(ctx, m.mediaID, srcURL, m.mediaFrom)
+	}
+
 	go m.ingestLoop(ctx, srcURL)
 	return media, nil
 }
 
+// fetchMeta — yt-dlp -J / плейлист / ffprobe; сбой не фатален: работаем как с live
+func (m *mediaSession) fetchMeta(ctx context.Context, srcURL string) *models.MediaMeta {
+	metaCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	meta, err := m.src.Meta(metaCtx, srcURL)
+	if err != nil {
+		m.logger.Printf("[META][WARN] media=%d err=%v", m.mediaID, err)
+		return nil
+	}
+
+	if err := m.repo.UpdateMediaMeta(ctx, m.mediaID, meta); err != nil {
+		m.logger.Printf("[META][DB][FAIL] media=%d err=%v", m.mediaID, err)
+	}
+
+	m.logger.Printf("[META] media=%d live=%v status=%s dur=%ds",
+		m.mediaID, meta.IsLive, meta.LiveStatus, meta.DurationSec)
+	return meta
+}
+
+// selectAudio — ffprobe входа ffmpeg и проверка выбора; проба не удалась →
+// номер дорожки / канал передаются как есть, язык не найти
+func (m *mediaSession) selectAudio(ctx context.Context, srcURL string) (models.AudioSelection, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, trackProbeTO)
+	tracks, err := m.probeTracks(probeCtx, srcURL)
+	cancel()
+	if err != nil {
+		m.logger.Printf("[TRACKS][WARN] media=%d err=%v", m.mediaID, err)
+	}
+
+	sel, err := stations.SelectTrack(tracks, m.opts.Audio)
+	if err != nil {
+		return sel, err
+	}
+
+	if len(tracks) > 0 {
+		m.logger.Printf("[TRACKS] media=%d count=%d track=%v channel=%q",
+			m.mediaID, len(tracks), trackNum(sel), sel.Channel)
+
+		m.events <- ports.ChunkEvent{
+			Type:    ports.EventTracks,
+			MediaID: m.mediaID,
+			RoomID:  m.roomID,
+			Tracks:  &models.TrackReport{Tracks: tracks, Selected: sel},
+		}
+	}
+	return sel, nil
+}
+
+// ProbeTracks — ports.TrackProber: дорожки источника, чтобы выбрать до старта
+func (m *MediaService) ProbeTracks(ctx context.Context, srcURL string) ([]models.AudioTrack, error) {
+	return m.probeTracks(ctx, srcURL)
+}
+
+func (m *MediaService) probeTracks(ctx context.Context, srcURL string) ([]models.AudioTrack, error) {
+	in, err := m.src.Resolve(ctx, srcURL)
+	if err != nil {
+		return nil, err
+	}
+	return stations.ProbeTracks(ctx, in)
+}
+
+func trackNum(sel models.AudioSelection) string {
+	if sel.Track == nil {
+		return "default"
+	}
+	return fmt.Sprint(*sel.Track)
+}
+
 // ========================================================================
 // LOOP
 // ========================================================================
-func (m *MediaService) ingestLoop(ctx context.Context, srcURL string) {
+func (m *mediaSession) ingestLoop(ctx context.Context, srcURL string) {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
 
-	for {
+	for tick := 1; ; tick++ {
 		select {
 		case <-ctx.Done():
-			m.logger.Printf("[INGEST-LOOP][STOP] media=%d", m.mediaID)
+			m.logger.Printf("[INGEST-LOOP][STOP] media=%d reason=%q", m.mediaID, m.endReason)
+			m.finish()
 			return
 		case <-ticker.C:
-			go m.ingestOne(ctx, srcURL)
 		}
+
+		if reason := m.streamEnded(ctx, srcURL, tick); reason != "" {
+			m.end(reason)
+			continue
+		}
+		if time.Now().UnixNano() < m.retryAt.Load() {
+			continue
+		}
+		go m.ingestOne(ctx, srcURL)
+	}
+}
+
+// streamEnded — причина конца эфира или "". Статус источника (yt-dlp
+// live_status, #EXT-X-ENDLIST) спрашиваем раз в liveCheckTicks и при сбоях подряд
+func (m *mediaSession) streamEnded(ctx context.Context, srcURL string, tick int) string {
+	fails := int(m.fails.Load())
+	if fails >= endMaxFails {
+		return models.MediaEndNoSignal
+	}
+
+	// без признака live при старте статус источника ничего не скажет
+	if !m.live || (!m.checkAll && fails < endCheckFails && tick%liveCheckTicks != 0) {
+		return ""
+	}
+
+	metaCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	meta, err := m.src.Meta(metaCtx, srcURL)
+	cancel()
+	if err != nil || meta.IsLive {
+		return ""
+	}
+
+	m.logger.Printf("[LIVE][OVER] media=%d status=%s fails=%d", m.mediaID, meta.LiveStatus, fails)
+	return models.MediaEndStreamEnded
+}
+
+// end — источник закончился: останавливаем ingest, запись эфира и т.п.
+func (m *mediaSession) end(reason string) {
+	m.endOnce.Do(func() {
+		m.endReason = reason
+		m.stop()
+	})
+}
+
+// finish — ingest остановлен; завершение media — только если его определил
+// источник, а не закрытие WS
+func (m *mediaSession) finish() {
+	if m.endReason != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := m.repo.FinishMedia(ctx, m.mediaID, m.endReason); err != nil {
+			m.logger.Printf("[FINISH][DB][FAIL] media=%d err=%v", m.mediaID, err)
+		}
+		cancel()
+
+		m.events <- ports.ChunkEvent{
+			Type:    ports.EventMediaFinished,
+			MediaID: m.mediaID,
+			RoomID:  m.roomID,
+			Text:    m.endReason,
+		}
+
+		if m.onEnded != nil {
+			m.onEnded(m.mediaID, m.endReason)
+		}
+	}
+
+	if m.onFinished != nil {
+		m.onFinished(m.mediaID)
 	}
 }
 
 // ========================================================================
 // ONE INGEST (БЕЗ СМЕЩЕНИЯ)
 // ========================================================================
-func (m *MediaService) ingestOne(ctx context.Context, srcURL string) {
+func (m *mediaSession) ingestOne(ctx context.Context, srcURL string) {
 	start := time.Now()
 	m.logger.Printf("[INGEST][START] media=%d", m.mediaID)
 
-	audioURL, err := m.s1.Run(ctx, srcURL)
+	audioURL, err := m.src.Resolve(ctx, srcURL)
 	if err != nil || audioURL == "" {
 		m.logger.Printf("[S1][FAIL] media=%d err=%v", m.mediaID, err)
+		m.failed(ctx, err)
+		return
+	}
+
+	capturedAt := time.Now()
+
+	// номер — в момент захвата: окна перекрываются, и S2 более раннего
+	// может закончиться позже. Окно без звука оставляет пропуск в нумерации.
+	// Номер не выдан — окно пропускаем: номер при вставке нарушил бы порядок
+	number, err := m.allocChunkNumber(ctx)
+	if err != nil {
+		m.logger.Printf("[SEQ][FAIL] media=%d err=%v", m.mediaID, err)
 		return
 	}
 
-	pcm, err := m.s2.Run(ctx, audioURL)
-	if err != nil || len(pcm) == 0 {
+	opts, preset := m.grabOptions(ctx, 0)
+
+	grab, err := m.s2.Run(ctx, audioURL, opts)
+	if errors.Is(err, stations.ErrStreamRejected) {
+		m.src.Invalidate(srcURL)
+	}
+	if err != nil || len(grab.PCM) == 0 {
 		m.logger.Printf("[S2][FAIL] media=%d err=%v", m.mediaID, err)
+		m.failed(ctx, err)
+		return
+	}
+	m.recovered()
+
+	m.processPCM(ctx, grab, preset, number, capturedAt, capturedAt.Sub(m.mediaFrom).Milliseconds(), start)
+}
+
+// allocChunkNumber — AllocChunkNumber с повтором: сбой БД не должен стоить окна
+func (m *mediaSession) allocChunkNumber(ctx context.Context) (int, error) {
+	var err error
+	for attempt := 1; attempt <= chunkSeqAttempts; attempt++ {
+		var n int
+		if n, err = m.repo.AllocChunkNumber(ctx, m.mediaID); err == nil {
+			return n, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(chunkSeqRetryPause):
+		}
+	}
+	return 0, err
+}
+
+// failed — сбой окна (err == nil — окно без звука); после отмены ctx не
+// считается. Класс ошибки источника задаёт паузу до следующего окна
+func (m *mediaSession) failed(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	m.fails.Add(1)
+
+	if delay, _ := sourceRetryDelay(err); delay > 0 {
+		m.retryAt.Store(time.Now().Add(delay).UnixNano())
+		m.logger.Printf("[SOURCE][BACKOFF] media=%d code=%s delay=%s",
+			m.mediaID, ports.SourceErrorCode(err), delay)
+	}
+	m.sourceError(err)
+}
+
+func (m *mediaSession) recovered() {
+	m.fails.Store(0)
+
+	m.errMu.Lock()
+	m.errCode = ""
+	m.errMu.Unlock()
+}
+
+// sourceError — классифицированный сбой клиенту; тот же код подряд — один раз
+func (m *mediaSession) sourceError(err error) {
+	code := ports.SourceErrorCode(err)
+	if code == "" {
+		return
+	}
+
+	m.errMu.Lock()
+	same := code == m.errCode
+	m.errCode = code
+	m.errMu.Unlock()
+	if same {
 		return
 	}
 
-	chunkID, filePath, err := m.createPendingChunk(ctx, pcm)
+	m.events <- ports.ChunkEvent{
+		Type:    ports.EventError,
+		MediaID: m.mediaID,
+		RoomID:  m.roomID,
+		Code:    code,
+		Text:    err.Error(),
+	}
+}
+
+// sourceRetryDelay — пауза перед повтором по классу ошибки источника;
+// retry=false — без вмешательства (куки, другой URL) повтор не поможет
+func sourceRetryDelay(err error) (delay time.Duration, retry bool) {
+	switch {
+	case errors.Is(err, ports.ErrSourceRateLimited):
+		return time.Minute, true
+	case errors.Is(err, ports.ErrSourceUnavailable):
+		// эфир ещё не начался / уже кончился — конец определит streamEnded
+		return 30 * time.Second, true
+	case errors.Is(err, ports.ErrSourceAuth),
+		errors.Is(err, ports.ErrSourceGeoBlocked),
+		errors.Is(err, ports.ErrSourceUnsupported):
+		return 2 * time.Minute, false
+	}
+	// сеть и неразобранное — следующим окном
+	return 0, true
+}
+
+// retryVOD — сбой окна записи: ждать и повторить или остановиться
+func (m *mediaSession) retryVOD(ctx context.Context, err error, attempt int) bool {
+	m.sourceError(err)
+
+	delay, retry := sourceRetryDelay(err)
+	if !retry || errors.Is(err, ports.ErrSourceUnavailable) || attempt > vodRetries {
+		return false
+	}
+
+	delay = max(delay, vodRetryPause)
+	m.logger.Printf("[INGEST-VOD][RETRY] media=%d attempt=%d delay=%s", m.mediaID, attempt, delay)
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(delay):
+		return true
+	}
+}
+
+// ========================================================================
+// VOD: запись целиком, окнами подряд
+// ========================================================================
+func (m *mediaSession) ingestVOD(ctx context.Context, srcURL string, durationSec int) {
+	defer func() {
+		m.logger.Printf("[INGEST-VOD][STOP] media=%d reason=%q", m.mediaID, m.endReason)
+		m.finish()
+		m.stop()
+	}()
+
+	offset := m.resumeOffset(ctx)
+	total := time.Duration(durationSec) * time.Second
+	window := stations.WindowSec * time.Second
+
+	m.logger.Printf("[INGEST-VOD][START] media=%d from=%s total=%s", m.mediaID, offset, total)
+
+	attempt := 0
+
+	for offset < total {
+		if ctx.Err() != nil {
+			return
+		}
+
+		start := time.Now()
+
+		audioURL, err := m.src.Resolve(ctx, srcURL)
+		if err != nil || audioURL == "" {
+			m.logger.Printf("[S1][FAIL] media=%d err=%v", m.mediaID, err)
+			if attempt++; m.retryVOD(ctx, err, attempt) {
+				continue
+			}
+			return
+		}
+
+		opts, preset := m.grabOptions(ctx, offset)
+
+		grab, err := m.s2.Run(ctx, audioURL, opts)
+		if errors.Is(err, stations.ErrStreamRejected) {
+			// URL протух посреди записи — один повтор со свежим
+			m.src.Invalidate(srcURL)
+			if audioURL, err = m.src.Resolve(ctx, srcURL); err == nil {
+				grab, err = m.s2.Run(ctx, audioURL, opts)
+			}
+		}
+		if err != nil {
+			m.logger.Printf("[S2][FAIL] media=%d offset=%s err=%v", m.mediaID, offset, err)
+			if attempt++; m.retryVOD(ctx, err, attempt) {
+				continue
+			}
+			return
+		}
+		attempt = 0
+		m.recovered()
+
+		if len(grab.PCM) == 0 {
+			// запись кончилась раньше заявленной длительности
+			m.end(models.MediaEndCompleted)
+			return
+		}
+
+		// VOD идёт окнами по очереди — номер при вставке
+		m.processPCM(ctx, grab, preset, 0, time.Now(), offset.Milliseconds(), start)
+		offset += window
+
+		m.progress(min(float64(offset)/float64(total), 1))
+	}
+
+	if ctx.Err() == nil {
+		m.end(models.MediaEndCompleted)
+	}
+}
+
+// grabOptions — пресет читается из media на каждое окно: смена через API
+// применяется без перезапуска сессии
+func (m *mediaSession) grabOptions(ctx context.Context, offset time.Duration) (stations.GrabOptions, string) {
+	opts := stations.GrabOptions{Offset: offset, Audio: m.opts.Audio}
+
+	preset := defaultFilterPreset()
+	if media, err := m.repo.GetMediaByID(ctx, m.mediaID); err == nil && media != nil && media.AudioFilter != "" {
+		preset = media.AudioFilter
+	}
+
+	chain, err := stations.FilterChain(preset)
+	if err != nil {
+		m.logger.Printf("[FILTER][WARN] media=%d err=%v", m.mediaID, err)
+		return opts, stations.FilterNone
+	}
+	opts.Filter = chain
+	return opts, preset
+}
+
+// FilterPresets — ports.AudioFilterManager
+func (m *MediaService) FilterPresets() map[string]string {
+	out := make(map[string]string)
+	for _, name := range stations.FilterPresetNames() {
+		out[name], _ = stations.FilterChain(name)
+	}
+	return out
+}
+
+func (m *MediaService) SetFilter(ctx context.Context, mediaID int, preset string) error {
+	if _, err := stations.FilterChain(preset); err != nil {
+		return err
+	}
+
+	media, err := m.repo.GetMediaByID(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+	if media == nil {
+		return ports.ErrMediaNotFound
+	}
+	return m.repo.SetMediaFilter(ctx, mediaID, preset)
+}
+
+// defaultFilterPreset — для media без своего пресета
+func defaultFilterPreset() string {
+	if p := os.Getenv("AUDIO_FILTER_PRESET"); p != "" {
+		return p
+	}
+	return stations.FilterNone
+}
+
+func (m *mediaSession) progress(p float64) {
+	m.events <- ports.ChunkEvent{
+		Type:     ports.EventProgress,
+		MediaID:  m.mediaID,
+		RoomID:   m.roomID,
+		Progress: p,
+	}
+}
+
+// resumeOffset — повторный запуск записи продолжает с конца готовых чанков
+func (m *mediaSession) resumeOffset(ctx context.Context) time.Duration {
+	chunks, err := m.repo.ListCompletedChunks(ctx, m.mediaID)
+	if err != nil {
+		return 0
+	}
+
+	var end int64
+	for _, c := range chunks {
+		if e := c.OffsetMs + int64(c.DurationMs); e > end {
+			end = e
+		}
+	}
+	return time.Duration(end) * time.Millisecond
+}
+
+// ========================================================================
+// PCM → TEXT
+// ========================================================================
+func (m *mediaSession) processPCM(
+	ctx context.Context,
+	grab *stations.GrabResult,
+	preset string,
+	number int,
+	capturedAt time.Time,
+	offsetMs int64,
+	start time.Time,
+) {
+	pcm := grab.PCM
+
+	chunk, err := m.createPendingChunk(ctx, pcm, number, capturedAt, offsetMs)
 	if err != nil {
 		m.logger.Printf("[PENDING][FAIL] media=%d err=%v", m.mediaID, err)
 		return
 	}
+	chunkID := chunk.ChunkNumber
+
+	// уровни до / после предобработки — для сравнения пресетов
+	if err := m.repo.SetChunkLevels(ctx, m.mediaID, chunkID, preset, grab.RMSBefore, grab.RMSAfter); err != nil {
+		m.logger.Printf("[LEVELS][DB][FAIL] media=%d chunk=%d err=%v", m.mediaID, chunkID, err)
+	}
 
+	// чанк не должен остаться pending: при любом сбое ниже закрываем его пустым
+	completed := false
 	defer func() {
-		_ = m.repo.CompleteChunk(ctx, m.mediaID, chunkID, "")
+		if !completed {
+			_ = m.repo.CompleteChunk(ctx, m.mediaID, chunkID, "", "")
+		}
 	}()
 
+	// все вызовы STT / LLM ниже попадают в ledger под этим чанком
+	ctx = ports.WithUsageScope(ctx, ports.UsageScope{
+		MediaID:     m.mediaID,
+		ChunkNumber: chunkID,
+		User:        m.user,
+	})
+
+	// глоссарий читаем на каждый чанк — правки видны сразу
+	terms, err := m.glossary.ListTerms(ctx, m.mediaID)
+	if err != nil {
+		m.logger.Printf("[GLOSSARY][WARN] media=%d err=%v", m.mediaID, err)
+	}
+
 	wav := m.s3.Run(pcm)
 
-	raw, err := m.s4.Run(ctx, wav)
+	// архив не должен тормозить STT и не обрывается вместе с WS
+	go m.archiveChunk(context.WithoutCancel(ctx), chunkID, chunk.FilePath, wav)
+
+	raw, err := m.s4.Run(ctx, wav, ports.RecognizeOptions{
+		Hints:    GlossaryHints(terms),
+		Language: m.opts.Language,
+	})
 	if err != nil || raw == "" {
 		m.logger.Printf("[S4][FAIL] media=%d chunk=%d err=%v", m.mediaID, chunkID, err)
 		return
 	}
 
 	// GPT БЕЗ prevText
-	proc, err := m.s5.Run(ctx, "", raw)
+	proc, err := m.s5.Run(ctx, "", raw, ports.ChunkOptions{
+		Glossary: terms,
+		Prompt:   m.opts.Prompt,
+	})
 	if err != nil || proc == "" {
 		m.logger.Printf("[S5][SKIP] media=%d chunk=%d err=%v", m.mediaID, chunkID, err)
 		return
 	}
 
-	if err := m.repo.CompleteChunk(ctx, m.mediaID, chunkID, proc); err != nil {
+	proc = ApplyGlossary(proc, terms)
+
+	if err := m.repo.CompleteChunk(ctx, m.mediaID, chunkID, raw, proc); err != nil {
 		m.logger.Printf("[DB][FAIL] media=%d chunk=%d err=%v", m.mediaID, chunkID, err)
 		return
 	}
+	completed = true
 
-	_ = os.Remove(filePath)
+	if m.onChunkDone != nil {
+		chunk.RawText = raw
+		chunk.Text = proc
+		chunk.Status = "done"
+		m.onChunkDone(*chunk)
+	}
 
 	m.events <- ports.ChunkEvent{
+		Type:        ports.EventChunk,
 		MediaID:     m.mediaID,
 		ChunkNumber: chunkID,
 		RoomID:      m.roomID,
@@ -198,22 +813,52 @@ func (m *MediaService) ingestOne(ctx context.Context, srcURL string) {
 // ========================================================================
 // CREATE PENDING
 // ========================================================================
-func (m *MediaService) createPendingChunk(ctx context.Context, pcm []byte) (int, string, error) {
-	dir := fmt.Sprintf("/tmp/journalist/media_%d", m.mediaID)
+func (m *mediaSession) createPendingChunk(ctx context.Context, pcm []byte, number int, capturedAt time.Time, offsetMs int64) (*models.MediaChunk, error) {
+	dir := filepath.Join(m.chunkDir, fmt.Sprintf("media_%d", m.mediaID))
 	_ = os.MkdirAll(dir, 0755)
 
-	filename := fmt.Sprintf("chunk_%d.pcm", time.Now().UnixNano())
+	filename := fmt.Sprintf("chunk_%d.pcm", capturedAt.UnixNano())
 	path := filepath.Join(dir, filename)
 
 	if err := os.WriteFile(path, pcm, 0644); err != nil {
-		return 0, "", err
+		return nil, err
 	}
 
-	chunk, err := m.repo.InsertPendingChunk(ctx, m.mediaID, path)
+	chunk, err := m.repo.InsertPendingChunk(ctx, &models.MediaChunk{
+		MediaID:     m.mediaID,
+		ChunkNumber: number,
+		FilePath:    path,
+		CapturedAt:  capturedAt,
+		OffsetMs:    offsetMs,
+		DurationMs:  pcmDurationMs(pcm),
+	})
 	if err != nil {
-		return 0, "", err
+		return nil, err
 	}
 
 	m.logger.Printf("[PENDING] media=%d chunk=%d", m.mediaID, chunk.ChunkNumber)
-	return chunk.ChunkNumber, path, nil
+	return chunk, nil
+}
+
+// archiveChunk — WAV чанка в storage; локальный PCM удаляем только после записи в БД
+func (m *mediaSession) archiveChunk(ctx context.Context, chunkNumber int, pcmPath string, wav []byte) {
+	key := fmt.Sprintf("media/%d/chunks/%06d.wav", m.mediaID, chunkNumber)
+
+	url, err := m.storage.Put(ctx, key, bytes.NewReader(wav), int64(len(wav)), "audio/wav")
+	if err != nil {
+		m.logger.Printf("[ARCHIVE][FAIL] media=%d chunk=%d err=%v", m.mediaID, chunkNumber, err)
+		return
+	}
+
+	if err := m.repo.SetChunkStorage(ctx, m.mediaID, chunkNumber, url); err != nil {
+		m.logger.Printf("[ARCHIVE][DB][FAIL] media=%d chunk=%d err=%v", m.mediaID, chunkNumber, err)
+		return
+	}
+
+	_ = os.Remove(pcmPath)
+}
+
+// PCM s16le mono 16 kHz
+func pcmDurationMs(pcm []byte) int {
+	return len(pcm) / 32
 }
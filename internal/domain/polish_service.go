@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+const (
+	polishWindowChars  = 6000 // сколько текста чанков отдаём модели за раз
+	polishContextChars = 600  // хвост уже отредактированного текста для стыковки
+	polishTimeout      = 30 * time.Minute
+)
+
+const polishSystemPrompt = `Ты — литературный редактор расшифровок устной речи.
+
+Тебе дают:
+context — КОНЕЦ уже отредактированного текста (может быть пустым);
+fragment — следующий кусок сырой расшифровки, склеенный из коротких кусков.
+
+ЗАДАЧА: верни fragment в виде чистого связного текста.
+— Исправь границы предложений, пунктуацию и регистр.
+— Разбей на абзацы по смыслу (пустая строка между абзацами).
+— Убери дубли на стыках кусков и слова-паразиты.
+— НЕ пересказывай, НЕ сокращай, НЕ добавляй ничего от себя.
+— Имена, числа и цитаты сохраняй дословно.
+— context не повторяй и не переписывай: продолжай его.
+— Верни ТОЛЬКО отредактированный fragment, без пояснений.`
+
+// PolishService — фоновая редактура полного транскрипта после окончания media
+type PolishService struct {
+	repo        ports.MediaRepository
+	transcripts ports.TranscriptRepository
+	llm         ports.LLMService
+
+	mu      sync.Mutex
+	running map[int]bool
+}
+
+func NewPolishService(
+	repo ports.MediaRepository,
+	transcripts ports.TranscriptRepository,
+	llm ports.LLMService,
+) *PolishService {
+	return &PolishService{
+		repo:        repo,
+		transcripts: transcripts,
+		llm:         llm,
+		running:     make(map[int]bool),
+	}
+}
+
+// Enqueue — не блокирует; повторный вызов для той же media во время работы игнорируется
+func (p *PolishService) Enqueue(mediaID int) {
+	p.mu.Lock()
+	if p.running[mediaID] {
+		p.mu.Unlock()
+		log.Printf("[POLISH][SKIP] media=%d already running", mediaID)
+		return
+	}
+	p.running[mediaID] = true
+	p.mu.Unlock()
+
+	go func() {
+		defer func() {
+			p.mu.Lock()
+			delete(p.running, mediaID)
+			p.mu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), polishTimeout)
+		defer cancel()
+
+		if err := p.Run(ctx, mediaID); err != nil {
+			log.Printf("[POLISH][FAIL] media=%d err=%v", mediaID, err)
+		}
+	}()
+}
+
+// Run — синхронно: окна чанков → LLM → одна версия kind=edited
+func (p *PolishService) Run(ctx context.Context, mediaID int) error {
+	start := time.Now()
+
+	chunks, err := p.repo.ListCompletedChunks(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+	if len(chunks) == 0 {
+		return fmt.Errorf("no completed chunks")
+	}
+
+	t, err := p.transcripts.InsertTranscript(ctx, &models.Transcript{
+		MediaID:   mediaID,
+		Kind:      models.TranscriptEdited,
+		Status:    models.TranscriptRunning,
+		ChunkFrom: chunks[0].ChunkNumber,
+		ChunkTo:   chunks[len(chunks)-1].ChunkNumber,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[POLISH][START] media=%d chunks=%d version=%d", mediaID, len(chunks), t.ID)
+
+	ctx = ports.WithUsageScope(ctx, ports.UsageScope{MediaID: mediaID})
+
+	var edited []string
+	for i, window := range polishWindows(chunks) {
+		out, err := p.llm.Complete(ctx, ports.CompletionRequest{
+			Operation: "polish",
+			System:    polishSystemPrompt,
+			User: fmt.Sprintf("context:\n%s\n\nfragment:\n%s",
+				tail(strings.Join(edited, "\n\n"), polishContextChars),
+				window,
+			),
+			MaxTokens: 4000,
+		})
+		if err != nil {
+			t.Status = models.TranscriptFailed
+			t.Error = fmt.Sprintf("window %d: %v", i, err)
+			_ = p.transcripts.UpdateTranscript(context.WithoutCancel(ctx), t)
+			return err
+		}
+
+		edited = append(edited, strings.TrimSpace(out))
+		log.Printf("[POLISH][WINDOW] media=%d window=%d chars=%d", mediaID, i, len(out))
+	}
+
+	t.Status = models.TranscriptDone
+	t.Text = strings.Join(edited, "\n\n")
+	if err := p.transcripts.UpdateTranscript(ctx, t); err != nil {
+		return err
+	}
+
+	log.Printf("[POLISH][DONE] media=%d version=%d dur=%s", mediaID, t.ID, time.Since(start))
+	return nil
+}
+
+// polishWindows — склеиваем чанки в окна по ~polishWindowChars, не разрывая чанк
+func polishWindows(chunks []models.MediaChunk) []string {
+	var windows []string
+	var sb strings.Builder
+
+	for _, c := range chunks {
+		txt := strings.TrimSpace(c.Text)
+		if txt == "" {
+			continue
+		}
+		if sb.Len() > 0 && sb.Len()+len(txt) > polishWindowChars {
+			windows = append(windows, sb.String())
+			sb.Reset()
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(txt)
+	}
+
+	if sb.Len() > 0 {
+		windows = append(windows, sb.String())
+	}
+	return windows
+}
+
+// tail — последние max байт по границе руны
+func tail(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	s = s[len(s)-max:]
+	for i := 0; i < len(s); i++ {
+		if utf8.RuneStart(s[i]) {
+			return s[i:]
+		}
+	}
+	return s
+}
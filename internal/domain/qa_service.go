@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+const (
+	qaSearchChunks = 12 // лучших по full-text
+	qaRecentChunks = 6  // плюс последние — для live и вопросов «что сейчас»
+)
+
+const qaSystemPrompt = `Ты отвечаешь редактору на вопрос по расшифровке трансляции.
+Трансляция может ещё идти: у тебя только уже расшифрованные куски.
+
+Куски даны в формате [#N] текст.
+
+ПРАВИЛА:
+— Отвечай ТОЛЬКО по кускам. Нет ответа в тексте — так и скажи.
+— После каждого утверждения ставь ссылку на кусок: [#N] (можно несколько).
+— Цитаты — дословно, в кавычках.
+— Кратко, по делу, на языке вопроса.`
+
+var reCitation = regexp.MustCompile(`\[#(\d+)\]`)
+
+type QAService struct {
+	repo ports.MediaRepository
+	llm  ports.LLMService
+}
+
+func NewQAService(repo ports.MediaRepository, llm ports.LLMService) *QAService {
+	return &QAService{repo: repo, llm: llm}
+}
+
+func (s *QAService) Ask(ctx context.Context, mediaID int, question string) (*models.Answer, error) {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return nil, fmt.Errorf("empty question")
+	}
+
+	found, err := s.repo.SearchChunks(ctx, mediaID, question, qaSearchChunks)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := s.repo.ListCompletedChunks(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if len(all) == 0 {
+		return nil, fmt.Errorf("no completed chunks yet")
+	}
+
+	picked := qaContext(found, all)
+
+	var sb strings.Builder
+	for _, c := range picked {
+		fmt.Fprintf(&sb, "[#%d] %s\n", c.ChunkNumber, strings.TrimSpace(c.Text))
+	}
+	fmt.Fprintf(&sb, "\nВопрос: %s", question)
+
+	ctx = ports.WithUsageScope(ctx, ports.UsageScope{MediaID: mediaID})
+
+	out, err := s.llm.Complete(ctx, ports.CompletionRequest{
+		Operation: "ask",
+		System:    qaSystemPrompt,
+		User:      sb.String(),
+		MaxTokens: 1200,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	answer := &models.Answer{
+		MediaID:    mediaID,
+		Question:   question,
+		Answer:     strings.TrimSpace(out),
+		Citations:  qaCitations(out, picked),
+		ChunksSeen: len(all),
+	}
+
+	log.Printf("[ASK][OK] media=%d context=%d citations=%d", mediaID, len(picked), len(answer.Citations))
+	return answer, nil
+}
+
+// qaContext — найденные full-text + последние чанки, без дублей, по порядку
+func qaContext(found, all []models.MediaChunk) []models.MediaChunk {
+	picked := make(map[int]models.MediaChunk, len(found)+qaRecentChunks)
+	for _, c := range found {
+		picked[c.ChunkNumber] = c
+	}
+
+	from := len(all) - qaRecentChunks
+	if from < 0 {
+		from = 0
+	}
+	for _, c := range all[from:] {
+		picked[c.ChunkNumber] = c
+	}
+
+	out := make([]models.MediaChunk, 0, len(picked))
+	for _, c := range picked {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ChunkNumber < out[j].ChunkNumber })
+	return out
+}
+
+// qaCitations — только те [#N], что реально были в контексте
+func qaCitations(answer string, picked []models.MediaChunk) []models.AnswerCitation {
+	byNumber := make(map[int]models.MediaChunk, len(picked))
+	for _, c := range picked {
+		byNumber[c.ChunkNumber] = c
+	}
+
+	seen := map[int]bool{}
+	cits := []models.AnswerCitation{}
+	for _, m := range reCitation.FindAllStringSubmatch(answer, -1) {
+		n, _ := strconv.Atoi(m[1])
+		c, ok := byNumber[n]
+		if !ok || seen[n] {
+			continue
+		}
+		seen[n] = true
+		cits = append(cits, models.AnswerCitation{
+			ChunkNumber: n,
+			Text:        c.Text,
+			OffsetMs:    c.OffsetMs,
+		})
+	}
+	return cits
+}
@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Vovarama1992/journalist/internal/domain/stations"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+const (
+	quoteWindowChars = 12000 // текста чанков на один запрос к LLM
+	quoteClipPadMs   = 1000  // запас до и после цитаты в клипе
+	pcmBytesPerMs    = 32    // s16le mono 16 kHz
+	wavHeaderSize    = 44    // заголовок S3PCMtoWAV
+)
+
+const quoteSystemPrompt = `Ты помогаешь журналисту выбрать цитаты из расшифровки.
+
+Каждый кусок расшифровки дан так:
+[#N]
+raw: как распознала система (дословно)
+text: очищенный вариант
+
+Найди яркие, содержательные ПРЯМЫЕ высказывания (позиция, оценка, цифры, обещания).
+Верни СТРОГО JSON-объект:
+{"quotes": [{"chunk": N, "speaker": "кто говорит или пусто", "text": "цитата из text", "verbatim": "тот же фрагмент ДОСЛОВНО из raw"}]}
+
+ПРАВИЛА:
+— verbatim копируй из raw символ в символ, ничего не исправляй.
+— text — соответствующий фрагмент из text.
+— Не выдумывай и не склеивай разные куски.
+— Если подходящих цитат нет — {"quotes": []}.`
+
+type QuoteService struct {
+	repo    ports.MediaRepository
+	quotes  ports.QuoteRepository
+	llm     ports.LLMService
+	storage ports.ObjectStorage
+	s3      *stations.S3PCMtoWAV
+}
+
+func NewQuoteService(
+	repo ports.MediaRepository,
+	quotes ports.QuoteRepository,
+	llm ports.LLMService,
+	storage ports.ObjectStorage,
+) *QuoteService {
+	return &QuoteService{
+		repo:    repo,
+		quotes:  quotes,
+		llm:     llm,
+		storage: storage,
+		s3:      stations.NewS3PCMtoWAV(),
+	}
+}
+
+type quoteLLMOut struct {
+	Quotes []struct {
+		Chunk    int    `json:"chunk"`
+		Speaker  string `json:"speaker"`
+		Text     string `json:"text"`
+		Verbatim string `json:"verbatim"`
+	} `json:"quotes"`
+}
+
+func (s *QuoteService) Extract(ctx context.Context, mediaID int) ([]models.Quote, error) {
+	chunks, err := s.repo.ListCompletedChunks(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("no completed chunks")
+	}
+
+	byNumber := make(map[int]models.MediaChunk, len(chunks))
+	for _, c := range chunks {
+		byNumber[c.ChunkNumber] = c
+	}
+
+	ctx = ports.WithUsageScope(ctx, ports.UsageScope{MediaID: mediaID})
+
+	var quotes []models.Quote
+	for _, window := range quoteWindows(chunks) {
+		out, err := s.llm.Complete(ctx, ports.CompletionRequest{
+			Operation: "quotes",
+			System:    quoteSystemPrompt,
+			User:      window,
+			MaxTokens: 3000,
+			JSON:      true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var parsed quoteLLMOut
+		if err := decodeLLMJSON(out, &parsed); err != nil {
+			return nil, err
+		}
+
+		for _, q := range parsed.Quotes {
+			c, ok := byNumber[q.Chunk]
+			if !ok || strings.TrimSpace(q.Text) == "" {
+				continue
+			}
+			quotes = append(quotes, placeQuote(c, q.Speaker, q.Text, q.Verbatim))
+		}
+	}
+
+	if err := s.quotes.ReplaceQuotes(ctx, mediaID, quotes); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[QUOTES][OK] media=%d chunks=%d quotes=%d", mediaID, len(chunks), len(quotes))
+	return quotes, nil
+}
+
+func (s *QuoteService) Clip(ctx context.Context, quoteID int) ([]byte, error) {
+	q, err := s.quotes.GetQuote(ctx, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	if q == nil {
+		return nil, ports.ErrQuoteNotFound
+	}
+
+	c, err := s.repo.GetChunk(ctx, q.MediaID, q.ChunkNumber)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || (c.FilePath == "" && c.StorageURL == "") {
+		return nil, ports.ErrQuoteAudioNotFound
+	}
+
+	pcm, err := s.chunkPCM(ctx, c)
+	if errors.Is(err, fs.ErrNotExist) {
+		// рабочий PCM уже удалён, а в архив чанк не попал
+		return nil, ports.ErrQuoteAudioNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read chunk audio: %w", err)
+	}
+
+	from := (q.OffsetMs - c.OffsetMs - quoteClipPadMs) * pcmBytesPerMs
+	to := (q.OffsetMs - c.OffsetMs + int64(q.DurationMs) + quoteClipPadMs) * pcmBytesPerMs
+
+	return s.s3.Run(slicePCM(pcm, from, to)), nil
+}
+
+// placeQuote — таймкоды цитаты: начало чанка + доля позиции verbatim в raw.
+// У STT нет пословных таймингов, поэтому позиция пропорциональна тексту.
+func placeQuote(c models.MediaChunk, speaker, text, verbatim string) models.Quote {
+	q := models.Quote{
+		MediaID:     c.MediaID,
+		ChunkNumber: c.ChunkNumber,
+		Speaker:     strings.TrimSpace(speaker),
+		Text:        strings.TrimSpace(text),
+		Verbatim:    strings.TrimSpace(verbatim),
+		OffsetMs:    c.OffsetMs,
+		DurationMs:  c.DurationMs,
+	}
+
+	raw := c.RawText
+	idx := strings.Index(strings.ToLower(raw), strings.ToLower(q.Verbatim))
+	if q.Verbatim == "" || idx < 0 {
+		// модель не попала в raw — честно отдаём весь распознанный кусок
+		q.Verbatim = raw
+		return q
+	}
+
+	total := utf8.RuneCountInString(raw)
+	if total == 0 {
+		return q
+	}
+	start := utf8.RuneCountInString(strings.ToLower(raw)[:idx])
+	length := utf8.RuneCountInString(q.Verbatim)
+
+	q.OffsetMs = c.OffsetMs + int64(c.DurationMs)*int64(start)/int64(total)
+	q.DurationMs = c.DurationMs * length / total
+	return q
+}
+
+// quoteWindows — куски расшифровки в формате промпта, по ~quoteWindowChars
+func quoteWindows(chunks []models.MediaChunk) []string {
+	var windows []string
+	var sb strings.Builder
+
+	for _, c := range chunks {
+		block := fmt.Sprintf("[#%d]\nraw: %s\ntext: %s\n\n",
+			c.ChunkNumber, strings.TrimSpace(c.RawText), strings.TrimSpace(c.Text))
+
+		if sb.Len() > 0 && sb.Len()+len(block) > quoteWindowChars {
+			windows = append(windows, sb.String())
+			sb.Reset()
+		}
+		sb.WriteString(block)
+	}
+
+	if sb.Len() > 0 {
+		windows = append(windows, sb.String())
+	}
+	return windows
+}
+
+// slicePCM — [from, to) в байтах, выровнено по сэмплу и обрезано по границам
+func slicePCM(pcm []byte, from, to int64) []byte {
+	from -= from % 2
+	to -= to % 2
+	if from < 0 {
+		from = 0
+	}
+	if to > int64(len(pcm)) {
+		to = int64(len(pcm))
+	}
+	if from >= to {
+		return pcm
+	}
+	return pcm[from:to]
+}
+
+// chunkPCM — из архива (WAV без заголовка) или рабочий PCM, если ещё не заархивирован
+func (s *QuoteService) chunkPCM(ctx context.Context, c *models.MediaChunk) ([]byte, error) {
+	if c.StorageURL == "" {
+		return os.ReadFile(c.FilePath)
+	}
+
+	rc, err := s.storage.Get(ctx, c.StorageURL)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	wav, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	if len(wav) < wavHeaderSize {
+		return nil, fmt.Errorf("archived chunk is not a wav")
+	}
+	return wav[wavHeaderSize:], nil
+}
@@ -0,0 +1,379 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/domain/stations"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+const (
+	scheduleTick       = 10 * time.Second
+	scheduleRetryDelay = 30 * time.Second
+	scheduleProbeTO    = 60 * time.Second
+	scheduleMaxSec     = 24 * 60 * 60
+	scheduleMaxPrompt  = 2000
+)
+
+// ScheduleService — запуск и остановка сессий по расписанию. Состояние
+// в БД: после рестарта running-сессии продолжаются в ту же media
+type ScheduleService struct {
+	repo     ports.ScheduleRepository
+	src      *stations.SourceRegistry
+	sessions *SessionManager
+
+	// переходы статуса (старт / отмена) — по одному
+	stateMu sync.Mutex
+
+	mu       sync.Mutex
+	starting map[int]bool      // проба потока / старт сессии идёт
+	nextTry  map[int]time.Time // поток не поднят → не раньше
+}
+
+func NewScheduleService(
+	repo ports.ScheduleRepository,
+	src *stations.SourceRegistry,
+	sessions *SessionManager,
+) *ScheduleService {
+	return &ScheduleService{
+		repo:     repo,
+		src:      src,
+		sessions: sessions,
+		starting: make(map[int]bool),
+		nextTry:  make(map[int]time.Time),
+	}
+}
+
+// Run — до отмены ctx
+func (s *ScheduleService) Run(ctx context.Context) {
+	ticker := time.NewTicker(scheduleTick)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ScheduleService) tick(ctx context.Context) {
+	list, err := s.repo.ListActiveSchedules(ctx)
+	if err != nil {
+		log.Printf("[SCHEDULE][LIST][FAIL] err=%v", err)
+		return
+	}
+
+	now := time.Now()
+	for i := range list {
+		sc := list[i]
+		key := scheduleKey(sc.ID)
+
+		switch {
+		case !now.Before(sc.EndAt()):
+			s.finish(ctx, &sc)
+
+		case sc.Status == models.ScheduleRunning:
+			if _, ok := s.sessions.Running(key); !ok && s.claim(sc.ID, now) {
+				// сервер перезапускался или сессия упала — продолжаем ту же media
+				go s.resume(ctx, sc)
+			}
+
+		case !now.Before(sc.StartAt) && s.claim(sc.ID, now):
+			go s.tryStart(ctx, sc)
+		}
+	}
+}
+
+// claim — пора пробовать и проба / старт ещё не идёт; помечает начатым
+func (s *ScheduleService) claim(id int, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.starting[id] || now.Before(s.nextTry[id]) {
+		return false
+	}
+	s.starting[id] = true
+	return true
+}
+
+func (s *ScheduleService) release(id int) {
+	s.mu.Lock()
+	delete(s.starting, id)
+	s.mu.Unlock()
+}
+
+// retryLater — следующая попытка не раньше scheduleRetryDelay;
+// лимит / куки / гео — пауза дольше обычной
+func (s *ScheduleService) retryLater(id int, err error) {
+	delay, _ := sourceRetryDelay(err)
+
+	s.mu.Lock()
+	s.nextTry[id] = time.Now().Add(max(delay, scheduleRetryDelay))
+	s.mu.Unlock()
+}
+
+// tryStart — поток поднят → сессия; нет → waiting и повтор через scheduleRetryDelay
+func (s *ScheduleService) tryStart(ctx context.Context, sc models.ScheduledSession) {
+	defer s.release(sc.ID)
+
+	probeCtx, cancel := context.WithTimeout(ctx, scheduleProbeTO)
+	_, probeErr := s.src.Resolve(probeCtx, sc.URL)
+	cancel()
+
+	s.stateMu.Lock()
+	// пока шла проба, сессию могли отменить или поправить
+	cur, err := s.repo.GetSchedule(ctx, sc.ID)
+	if err != nil || cur == nil || !cur.Active() || cur.Status == models.ScheduleRunning {
+		s.stateMu.Unlock()
+		return
+	}
+
+	if probeErr != nil {
+		s.retryLater(sc.ID, probeErr)
+
+		cur.Status = models.ScheduleWaiting
+		cur.Attempts++
+		cur.LastError = probeErr.Error()
+		s.save(ctx, cur)
+		s.stateMu.Unlock()
+
+		log.Printf("[SCHEDULE][WAIT] id=%d attempt=%d err=%v", cur.ID, cur.Attempts, probeErr)
+		return
+	}
+	s.stateMu.Unlock()
+
+	s.start(ctx, cur, 0)
+}
+
+// resume — running в БД, но сессии нет: та же media, повтор — как у tryStart
+func (s *ScheduleService) resume(ctx context.Context, sc models.ScheduledSession) {
+	defer s.release(sc.ID)
+
+	mediaID := 0
+	if sc.MediaID != nil {
+		mediaID = *sc.MediaID
+	}
+	s.start(ctx, &sc, mediaID)
+}
+
+// start — sessions.Start (полный Process) без stateMu; итог пишется под
+// stateMu по свежей строке: отменённую / завершённую за это время — останавливаем
+func (s *ScheduleService) start(ctx context.Context, sc *models.ScheduledSession, mediaID int) {
+	user := sc.User
+	if user == "" {
+		user = fmt.Sprintf("schedule:%d", sc.ID)
+	}
+
+	id, err := s.sessions.Start(scheduleKey(sc.ID), sc.URL, sc.RoomID, mediaID, user, ports.SessionOptions{
+		Language: sc.Language,
+		Prompt:   sc.Prompt,
+	})
+
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	cur, gerr := s.repo.GetSchedule(ctx, sc.ID)
+	if gerr != nil || cur == nil || !cur.Active() {
+		if err == nil {
+			s.sessions.Stop(scheduleKey(sc.ID))
+		}
+		return
+	}
+
+	if errors.Is(err, ports.ErrMediaFinished) {
+		// эфир кончился раньше max_duration — сессия выполнена
+		cur.Status = models.ScheduleRunning
+		s.close(ctx, cur)
+		return
+	}
+	if err != nil {
+		s.retryLater(cur.ID, err)
+
+		cur.Attempts++
+		cur.LastError = err.Error()
+		s.save(ctx, cur)
+
+		log.Printf("[SCHEDULE][START][FAIL] id=%d attempt=%d err=%v", cur.ID, cur.Attempts, err)
+		return
+	}
+
+	if cur.StartedAt == nil {
+		now := time.Now()
+		cur.StartedAt = &now
+	}
+	cur.Status = models.ScheduleRunning
+	cur.MediaID = &id
+	cur.LastError = ""
+	s.save(ctx, cur)
+
+	log.Printf("[SCHEDULE][START] id=%d media=%d room=%s resume=%v until=%s",
+		cur.ID, id, cur.RoomID, mediaID > 0, cur.EndAt().Format(time.RFC3339))
+}
+
+// finish — время вышло: идущая сессия останавливается, не стартовавшая — failed
+func (s *ScheduleService) finish(ctx context.Context, sc *models.ScheduledSession) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	s.close(ctx, sc)
+}
+
+// close — под stateMu
+func (s *ScheduleService) close(ctx context.Context, sc *models.ScheduledSession) {
+	s.sessions.Stop(scheduleKey(sc.ID))
+	s.forget(sc.ID)
+
+	now := time.Now()
+	sc.FinishedAt = &now
+	if sc.Status == models.ScheduleRunning {
+		sc.Status = models.ScheduleDone
+	} else {
+		sc.Status = models.ScheduleFailed
+		if sc.LastError == "" {
+			sc.LastError = "stream did not come up"
+		}
+	}
+	s.save(ctx, sc)
+
+	log.Printf("[SCHEDULE][FINISH] id=%d status=%s", sc.ID, sc.Status)
+}
+
+func (s *ScheduleService) save(ctx context.Context, sc *models.ScheduledSession) {
+	if err := s.repo.SetScheduleState(ctx, sc); err != nil {
+		log.Printf("[SCHEDULE][DB][FAIL] id=%d err=%v", sc.ID, err)
+	}
+}
+
+func (s *ScheduleService) forget(id int) {
+	s.mu.Lock()
+	delete(s.nextTry, id)
+	s.mu.Unlock()
+}
+
+func (s *ScheduleService) Create(ctx context.Context, sc *models.ScheduledSession) error {
+	if err := validateSchedule(sc); err != nil {
+		return err
+	}
+	sc.Status = models.SchedulePlanned
+
+	if err := s.repo.InsertSchedule(ctx, sc); err != nil {
+		return err
+	}
+
+	log.Printf("[SCHEDULE][CREATE] id=%d url=%s room=%s at=%s dur=%ds",
+		sc.ID, sc.URL, sc.RoomID, sc.StartAt.Format(time.RFC3339), sc.MaxDurationSec)
+	return nil
+}
+
+func (s *ScheduleService) Update(ctx context.Context, sc *models.ScheduledSession) error {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	cur, err := s.repo.GetSchedule(ctx, sc.ID)
+	if err != nil {
+		return err
+	}
+	if cur == nil {
+		return ports.ErrScheduleNotFound
+	}
+	if cur.Status != models.SchedulePlanned && cur.Status != models.ScheduleWaiting {
+		return ports.ErrScheduleState
+	}
+	if err := validateSchedule(sc); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateSchedule(ctx, sc); err != nil {
+		return err
+	}
+	// новый URL / время — пробуем сразу, не дожидаясь повтора
+	s.forget(sc.ID)
+
+	sc.Status, sc.Attempts, sc.LastError = cur.Status, cur.Attempts, cur.LastError
+	sc.CreatedAt = cur.CreatedAt
+	return nil
+}
+
+func (s *ScheduleService) Cancel(ctx context.Context, id int) (*models.ScheduledSession, error) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	sc, err := s.repo.GetSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sc == nil {
+		return nil, ports.ErrScheduleNotFound
+	}
+	if !sc.Active() {
+		return nil, ports.ErrScheduleState
+	}
+
+	s.sessions.Stop(scheduleKey(id))
+	s.forget(id)
+
+	now := time.Now()
+	sc.Status = models.ScheduleCanceled
+	sc.FinishedAt = &now
+	if err := s.repo.SetScheduleState(ctx, sc); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[SCHEDULE][CANCEL] id=%d", id)
+	return sc, nil
+}
+
+func (s *ScheduleService) Get(ctx context.Context, id int) (*models.ScheduledSession, error) {
+	sc, err := s.repo.GetSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sc == nil {
+		return nil, ports.ErrScheduleNotFound
+	}
+	return sc, nil
+}
+
+func (s *ScheduleService) List(ctx context.Context, status string, limit, offset int) ([]models.ScheduledSession, error) {
+	return s.repo.ListSchedules(ctx, status, limit, offset)
+}
+
+func scheduleKey(id int) string { return fmt.Sprintf("schedule:%d", id) }
+
+func validateSchedule(sc *models.ScheduledSession) error {
+	sc.URL = strings.TrimSpace(sc.URL)
+	if u, err := url.Parse(sc.URL); err != nil || u.Scheme == "" {
+		return fmt.Errorf("%w: url is required", ports.ErrScheduleInvalid)
+	}
+	if strings.TrimSpace(sc.RoomID) == "" {
+		return fmt.Errorf("%w: roomID is required", ports.ErrScheduleInvalid)
+	}
+	if sc.StartAt.IsZero() {
+		return fmt.Errorf("%w: startAt is required", ports.ErrScheduleInvalid)
+	}
+	if sc.MaxDurationSec <= 0 || sc.MaxDurationSec > scheduleMaxSec {
+		return fmt.Errorf("%w: maxDurationSec must be 1..%d", ports.ErrScheduleInvalid, scheduleMaxSec)
+	}
+	if !sc.EndAt().After(time.Now()) {
+		return fmt.Errorf("%w: session would already be over", ports.ErrScheduleInvalid)
+	}
+	if sc.Language != "" && !ports.ValidLanguage(sc.Language) {
+		return fmt.Errorf("%w: language must look like ru-RU", ports.ErrScheduleInvalid)
+	}
+	if len([]rune(sc.Prompt)) > scheduleMaxPrompt {
+		return fmt.Errorf("%w: prompt is longer than %d", ports.ErrScheduleInvalid, scheduleMaxPrompt)
+	}
+	return nil
+}
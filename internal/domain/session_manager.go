@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+// SessionManager — сессии, запущенные сервером без WS-клиента (watcher,
+// расписание): у каждой свой ctx, остановка — по ключу
+type SessionManager struct {
+	media ports.MediaProcessor
+	repo  ports.MediaRepository
+
+	mu      sync.Mutex
+	running map[string]*managedSession
+}
+
+type managedSession struct {
+	mediaID int
+	started time.Time
+	cancel  context.CancelFunc
+
+	// Process идёт без s.mu: ключ занят сразу, ready закрывается, когда
+	// mediaID / err известны
+	ready chan struct{}
+	err   error
+}
+
+// isReady — Process уже вернулся
+func (m *managedSession) isReady() bool {
+	select {
+	case <-m.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewSessionManager(media ports.MediaProcessor, repo ports.MediaRepository) *SessionManager {
+	return &SessionManager{
+		media:   media,
+		repo:    repo,
+		running: make(map[string]*managedSession),
+	}
+}
+
+// Start — Process в фоне; mediaID > 0 → продолжить существующую media
+// (законченную — ErrMediaFinished). Под тем же ключом уже идёт сессия → она и возвращается
+func (s *SessionManager) Start(
+	key, srcURL, roomID string,
+	mediaID int,
+	user string,
+	opts ports.SessionOptions,
+) (int, error) {
+	s.mu.Lock()
+	if cur, ok := s.running[key]; ok {
+		s.mu.Unlock()
+		// её ещё запускают — ждём результат того запуска
+		<-cur.ready
+		return cur.mediaID, cur.err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = ports.WithUsageScope(ctx, ports.UsageScope{User: user})
+	ctx = ports.WithSessionOptions(ctx, opts)
+
+	cur := &managedSession{started: time.Now(), cancel: cancel, ready: make(chan struct{})}
+	s.running[key] = cur
+	s.mu.Unlock()
+
+	// metadata, resolve, дорожки — десятки секунд; Running / Stop / Ended не ждут
+	id, err := s.process(ctx, srcURL, roomID, mediaID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer close(cur.ready)
+
+	stopped := s.running[key] != cur
+	switch {
+	case err != nil:
+		cancel()
+		if !stopped {
+			delete(s.running, key)
+		}
+		cur.err = err
+		return 0, err
+	case stopped:
+		// Stop пришёл, пока шёл Process: ctx уже отменён, сессия сворачивается
+		cur.err = context.Canceled
+		return 0, cur.err
+	}
+
+	cur.mediaID = id
+	log.Printf("[SESSION][START] key=%s media=%d room=%s", key, id, roomID)
+	return id, nil
+}
+
+func (s *SessionManager) process(ctx context.Context, srcURL, roomID string, mediaID int) (int, error) {
+	if mediaID > 0 {
+		media, err := s.repo.GetMediaByID(ctx, mediaID)
+		if err != nil {
+			return 0, err
+		}
+		if media != nil && media.FinishedAt != nil {
+			return 0, ports.ErrMediaFinished
+		}
+	}
+
+	media, err := s.media.Process(ctx, srcURL, roomID, mediaID)
+	if err != nil {
+		return 0, err
+	}
+	return media.ID, nil
+}
+
+// Stop — false, если под ключом ничего не шло
+func (s *SessionManager) Stop(key string) bool {
+	s.mu.Lock()
+	cur, ok := s.running[key]
+	delete(s.running, key)
+	s.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	cur.cancel()
+
+	log.Printf("[SESSION][STOP] key=%s media=%d after=%s", key, cur.mediaID, time.Since(cur.started).Round(time.Second))
+	return true
+}
+
+// Running — media идущей сессии; запускаемая ещё не считается
+func (s *SessionManager) Running(key string) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur, ok := s.running[key]
+	if !ok || !cur.isReady() {
+		return 0, false
+	}
+	return cur.mediaID, true
+}
+
+// Ended — MediaService.OnEnded: сессия завершилась сама (эфир кончился)
+func (s *SessionManager) Ended(mediaID int, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, cur := range s.running {
+		if cur.isReady() && cur.mediaID == mediaID {
+			cur.cancel()
+			delete(s.running, key)
+			log.Printf("[SESSION][ENDED] key=%s media=%d reason=%s", key, mediaID, reason)
+		}
+	}
+}
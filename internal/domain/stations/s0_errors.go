@@ -0,0 +1,122 @@
+package stations
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+// sourceErrorRules — маркеры stderr (в нижнем регистре) по классам.
+// Порядок важен: YouTube пишет «Video unavailable» и при гео-блоке,
+// и при лимите, и для спонсорских видео — частные классы проверяются раньше
+var sourceErrorRules = []struct {
+	kind    error
+	markers []string
+}{
+	{ports.ErrSourceGeoBlocked, []string{
+		"not available in your country",
+		"not made this video available in your country",
+		"blocked it in your country",
+		"geo restrict",
+		"geo-restrict",
+		"not available from your location",
+	}},
+	{ports.ErrSourceRateLimited, []string{
+		"http error 429",
+		"429 too many requests",
+		"too many requests",
+		"rate-limit",
+		"rate limit",
+		"try again later",
+	}},
+	{ports.ErrSourceAuth, []string{
+		"sign in to confirm",
+		"login required",
+		"requires authentication",
+		"members-only",
+		"available to this channel's members",
+		"join this channel",
+		"--cookies",
+		"http error 401",
+		"401 unauthorized",
+	}},
+	{ports.ErrSourceUnavailable, []string{
+		"private video",
+		"video is private",
+		"video unavailable",
+		"has been removed",
+		"does not exist",
+		"no longer available",
+		"has been terminated",
+		"this live event will begin",
+		"premieres in",
+		"http error 404",
+		"http error 410",
+		"no such file or directory",
+	}},
+	{ports.ErrSourceUnsupported, []string{
+		"unsupported url",
+		"is not a valid url",
+		"no video formats found",
+		"requested format is not available",
+		"invalid data found when processing input",
+		"protocol not found",
+		"does not contain any stream",
+	}},
+	{ports.ErrSourceNetwork, []string{
+		"unable to download webpage",
+		"connection refused",
+		"connection reset",
+		"connection timed out",
+		"timed out",
+		"temporary failure in name resolution",
+		"name or service not known",
+		"failed to resolve",
+		"network is unreachable",
+		"no route to host",
+		"remote end closed connection",
+		"http error 5",
+		"server returned 5",
+		"end of file",
+		"i/o error",
+	}},
+}
+
+// classifyStderr — класс сбоя по stderr инструмента; nil — не распознан
+func classifyStderr(tool, stderr string) error {
+	low := strings.ToLower(stderr)
+
+	for _, r := range sourceErrorRules {
+		for _, mk := range r.markers {
+			if strings.Contains(low, mk) {
+				return &ports.SourceError{Kind: r.kind, Tool: tool, Detail: stderrLine(stderr, mk)}
+			}
+		}
+	}
+	return nil
+}
+
+// stderrLine — строка с маркером (обычно «ERROR: [youtube] id: ...»)
+func stderrLine(stderr, marker string) string {
+	for _, ln := range strings.Split(stderr, "\n") {
+		if strings.Contains(strings.ToLower(ln), marker) {
+			return trim(strings.TrimSpace(ln), 200)
+		}
+	}
+	return trim(strings.TrimSpace(stderr), 200)
+}
+
+// probeError — ошибка ffprobe: класс по stderr, иначе как есть
+func probeError(what string, err error) error {
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		if cerr := classifyStderr("ffprobe", string(ee.Stderr)); cerr != nil {
+			return cerr
+		}
+		return fmt.Errorf("%s: %w: %s", what, err, trim(strings.TrimSpace(string(ee.Stderr)), 200))
+	}
+	return fmt.Errorf("%s: %w", what, err)
+}
@@ -0,0 +1,371 @@
+package stations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+// SourceKind — как читать источник
+type SourceKind string
+
+const (
+	SourcePage    SourceKind = "page"    // страница (YouTube, VK, ...) → yt-dlp
+	SourceHLS     SourceKind = "hls"     // прямой m3u8
+	SourceIcecast SourceKind = "icecast" // бесконечный аудиопоток по HTTP
+	SourceStream  SourceKind = "stream"  // rtmp / srt / rtsp от своего энкодера
+	SourceFile    SourceKind = "file"    // конечный файл: локальный или по HTTP
+)
+
+const sourceProbeTO = 10 * time.Second
+
+// SourceAdapter — путь от URL источника до входа ffmpeg (S2)
+type SourceAdapter interface {
+	// Resolve — URL / путь, который можно отдать ffmpeg -i
+	Resolve(ctx context.Context, srcURL string) (string, error)
+	// Meta — что за источник; от IsLive зависит live / VOD режим
+	Meta(ctx context.Context, srcURL string) (*models.MediaMeta, error)
+	// Invalidate — ffmpeg получил 403/404 на выданный URL
+	Invalidate(srcURL string)
+}
+
+// SourceRegistry — стоит перед S1: yt-dlp только для страниц,
+// прямые потоки и файлы идут в ffmpeg как есть
+type SourceRegistry struct {
+	adapters map[SourceKind]SourceAdapter
+	client   *http.Client
+
+	mu    sync.Mutex
+	kinds map[string]SourceKind // srcURL → определённый тип
+}
+
+// fileDirs — откуда разрешено читать локальные файлы (пустые пропускаются)
+func NewSourceRegistry(s1 *S1ResolveURL, s1meta *S1Metadata, fileDirs ...string) *SourceRegistry {
+	client := &http.Client{Timeout: sourceProbeTO}
+
+	r := &SourceRegistry{
+		adapters: make(map[SourceKind]SourceAdapter),
+		client:   client,
+		kinds:    make(map[string]SourceKind),
+	}
+
+	r.Register(SourcePage, &pageSource{s1: s1, meta: s1meta})
+	r.Register(SourceHLS, &hlsSource{client: client})
+	r.Register(SourceIcecast, &icecastSource{client: client})
+	r.Register(SourceStream, &streamSource{})
+	fs := &fileSource{}
+	for _, d := range fileDirs {
+		if d != "" {
+			fs.dirs = append(fs.dirs, filepath.Clean(d))
+		}
+	}
+	r.Register(SourceFile, fs)
+
+	return r
+}
+
+// Register — добавить / заменить адаптер
+func (r *SourceRegistry) Register(kind SourceKind, a SourceAdapter) {
+	r.adapters[kind] = a
+}
+
+func (r *SourceRegistry) Resolve(ctx context.Context, srcURL string) (string, error) {
+	a, err := r.adapter(ctx, srcURL)
+	if err != nil {
+		return "", err
+	}
+	return a.Resolve(ctx, srcURL)
+}
+
+func (r *SourceRegistry) Meta(ctx context.Context, srcURL string) (*models.MediaMeta, error) {
+	a, err := r.adapter(ctx, srcURL)
+	if err != nil {
+		return nil, err
+	}
+	return a.Meta(ctx, srcURL)
+}
+
+func (r *SourceRegistry) Invalidate(srcURL string) {
+	r.mu.Lock()
+	kind, ok := r.kinds[srcURL]
+	r.mu.Unlock()
+	if !ok {
+		// не кэшируется только page после неудачной пробы
+		kind = SourcePage
+	}
+
+	if a := r.adapters[kind]; a != nil {
+		a.Invalidate(srcURL)
+	}
+}
+
+func (r *SourceRegistry) adapter(ctx context.Context, srcURL string) (SourceAdapter, error) {
+	kind := r.Detect(ctx, srcURL)
+
+	a, ok := r.adapters[kind]
+	if !ok {
+		return nil, fmt.Errorf("no source adapter for %s", kind)
+	}
+	return a, nil
+}
+
+// Detect — по схеме, расширению, а для http — по ответу сервера.
+// Результат кэшируется: зовётся на каждый тик ingest. Неудачная проба
+// (сеть, отмена, 4xx/5xx) — page без кэша: поток, ещё не поднятый
+// (расписание), при следующей попытке проверится заново
+func (r *SourceRegistry) Detect(ctx context.Context, srcURL string) SourceKind {
+	r.mu.Lock()
+	kind, ok := r.kinds[srcURL]
+	r.mu.Unlock()
+	if ok {
+		return kind
+	}
+
+	kind, sure := r.detect(ctx, srcURL)
+	log.Printf("[S0][DETECT] src=%q kind=%s cached=%v", srcURL, kind, sure)
+
+	if sure {
+		r.mu.Lock()
+		r.kinds[srcURL] = kind
+		r.mu.Unlock()
+	}
+	return kind
+}
+
+// detect — sure=false: проба не удалась, page — лишь догадка
+func (r *SourceRegistry) detect(ctx context.Context, srcURL string) (kind SourceKind, sure bool) {
+	if strings.HasPrefix(srcURL, "/") {
+		return SourceFile, true
+	}
+
+	u, err := url.Parse(srcURL)
+	if err != nil {
+		return SourcePage, true
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "file":
+		return SourceFile, true
+	case "rtmp", "rtmps", "srt", "rtsp":
+		return SourceStream, true
+	case "http", "https":
+	default:
+		return SourcePage, true
+	}
+
+	if strings.EqualFold(path.Ext(u.Path), ".m3u8") {
+		return SourceHLS, true
+	}
+
+	// по заголовкам: тело не читаем — Icecast отдаёт его бесконечно
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srcURL, nil)
+	if err != nil {
+		return SourcePage, true
+	}
+	req.Header.Set("Icy-MetaData", "1")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		log.Printf("[S0][PROBE][ERR] src=%q err=%v", srcURL, err)
+		return SourcePage, false
+	}
+	resp.Body.Close()
+
+	ct := strings.ToLower(resp.Header.Get("Content-Type"))
+	icy := resp.Header.Get("Icy-Name") != "" || resp.Header.Get("Icy-Metaint") != ""
+
+	switch {
+	case resp.StatusCode >= 400:
+		log.Printf("[S0][PROBE][ERR] src=%q status=%d", srcURL, resp.StatusCode)
+		return SourcePage, false
+	case strings.Contains(ct, "mpegurl"):
+		return SourceHLS, true
+	case strings.HasPrefix(ct, "audio/") || strings.HasPrefix(ct, "video/") || ct == "application/ogg":
+		// без длины или с icy-* — эфир; иначе обычный файл
+		if icy || resp.ContentLength < 0 {
+			return SourceIcecast, true
+		}
+		return SourceFile, true
+	}
+	return SourcePage, true
+}
+
+// ========================================================================
+// PAGE: yt-dlp (S1)
+// ========================================================================
+type pageSource struct {
+	s1   *S1ResolveURL
+	meta *S1Metadata
+}
+
+func (p *pageSource) Resolve(ctx context.Context, srcURL string) (string, error) {
+	return p.s1.Run(ctx, srcURL)
+}
+
+func (p *pageSource) Meta(ctx context.Context, srcURL string) (*models.MediaMeta, error) {
+	return p.meta.Run(ctx, srcURL)
+}
+
+func (p *pageSource) Invalidate(srcURL string) { p.s1.Invalidate(srcURL) }
+
+// ========================================================================
+// ICECAST / RTMP / SRT: ffmpeg читает напрямую, всегда live
+// ========================================================================
+type icecastSource struct {
+	client *http.Client
+}
+
+func (s *icecastSource) Resolve(_ context.Context, srcURL string) (string, error) {
+	return srcURL, nil
+}
+
+func (s *icecastSource) Meta(ctx context.Context, srcURL string) (*models.MediaMeta, error) {
+	meta := &models.MediaMeta{IsLive: true, LiveStatus: "is_live"}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srcURL, nil)
+	if err != nil {
+		return meta, nil
+	}
+	req.Header.Set("Icy-MetaData", "1")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return meta, nil
+	}
+	resp.Body.Close()
+
+	meta.Title = resp.Header.Get("Icy-Name")
+	meta.Uploader = resp.Header.Get("Icy-Description")
+	return meta, nil
+}
+
+func (s *icecastSource) Invalidate(string) {}
+
+type streamSource struct{}
+
+func (s *streamSource) Resolve(_ context.Context, srcURL string) (string, error) {
+	return srcURL, nil
+}
+
+func (s *streamSource) Meta(_ context.Context, srcURL string) (*models.MediaMeta, error) {
+	title := srcURL
+	if u, err := url.Parse(srcURL); err == nil {
+		title = u.Host + u.Path
+	}
+	return &models.MediaMeta{Title: title, IsLive: true, LiveStatus: "is_live"}, nil
+}
+
+func (s *streamSource) Invalidate(string) {}
+
+// ========================================================================
+// FILE: локальный (только из dirs) или по HTTP; длительность — ffprobe
+// ========================================================================
+type fileSource struct {
+	dirs []string // пусто → локальные файлы запрещены
+}
+
+var errFileSourceDisabled = errors.New("local file sources are disabled")
+
+func (s *fileSource) Resolve(_ context.Context, srcURL string) (string, error) {
+	if strings.HasPrefix(srcURL, "http://") || strings.HasPrefix(srcURL, "https://") {
+		return srcURL, nil
+	}
+	return s.localPath(srcURL)
+}
+
+// localPath — путь внутри одного из dirs; выход за их пределы запрещён
+func (s *fileSource) localPath(srcURL string) (string, error) {
+	if len(s.dirs) == 0 {
+		return "", errFileSourceDisabled
+	}
+
+	p := strings.TrimPrefix(srcURL, "file://")
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(s.dirs[0], p)
+	}
+	p = filepath.Clean(p)
+
+	for _, dir := range s.dirs {
+		rel, err := filepath.Rel(dir, p)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("file %q is outside of source dirs", srcURL)
+}
+
+func (s *fileSource) Meta(ctx context.Context, srcURL string) (*models.MediaMeta, error) {
+	in, err := s.Resolve(ctx, srcURL)
+	if err != nil {
+		return nil, err
+	}
+	return ProbeFile(ctx, in)
+}
+
+// ErrNoAudio — в файле нет аудиодорожки
+var ErrNoAudio = errors.New("no audio stream")
+
+type ffprobeOut struct {
+	Streams []struct {
+		CodecType string `json:"codec_type"`
+	} `json:"streams"`
+	Format struct {
+		Duration string `json:"duration"`
+	} `json:"format"`
+}
+
+// ProbeFile — длительность и наличие аудио (ffprobe); для файлов и загрузок
+func ProbeFile(ctx context.Context, in string) (*models.MediaMeta, error) {
+	out, err := exec.CommandContext(ctx,
+		"ffprobe",
+		"-v", "error",
+		"-show_entries", "format=duration:stream=codec_type",
+		"-of", "json",
+		in,
+	).Output()
+	if err != nil {
+		return nil, probeError("ffprobe", err)
+	}
+
+	var probe ffprobeOut
+	if err := json.Unmarshal(out, &probe); err != nil {
+		return nil, fmt.Errorf("ffprobe decode: %w", err)
+	}
+
+	hasAudio := false
+	for _, st := range probe.Streams {
+		if st.CodecType == "audio" {
+			hasAudio = true
+			break
+		}
+	}
+	if !hasAudio {
+		return nil, ErrNoAudio
+	}
+
+	sec, err := strconv.ParseFloat(probe.Format.Duration, 64)
+	if err != nil || sec <= 0 {
+		return nil, fmt.Errorf("ffprobe duration %q", trim(probe.Format.Duration, 40))
+	}
+
+	return &models.MediaMeta{
+		Title:       path.Base(in),
+		DurationSec: int(sec + 0.999),
+		LiveStatus:  "not_live",
+	}, nil
+}
+
+func (s *fileSource) Invalidate(string) {}
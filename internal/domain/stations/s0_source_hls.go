@@ -0,0 +1,206 @@
+package stations
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+const maxPlaylistBytes = 4 << 20
+
+// hlsSource — прямой m3u8: из master-плейлиста берём аудио-дорожку
+// или самый лёгкий вариант — видео в высоком качестве нам не нужно
+type hlsSource struct {
+	client *http.Client
+}
+
+type hlsPlaylist struct {
+	master   bool
+	audio    string // URI из #EXT-X-MEDIA:TYPE=AUDIO
+	variant  string // вариант с минимальным BANDWIDTH
+	ended    bool   // #EXT-X-ENDLIST → запись, не эфир
+	duration float64
+}
+
+func (s *hlsSource) Resolve(ctx context.Context, srcURL string) (string, error) {
+	pl, err := s.fetch(ctx, srcURL)
+	if err != nil {
+		return "", err
+	}
+	if !pl.master {
+		return srcURL, nil
+	}
+
+	pick := pl.audio
+	if pick == "" {
+		pick = pl.variant
+	}
+	if pick == "" {
+		return srcURL, nil
+	}
+
+	out, err := resolveRef(srcURL, pick)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("[S0][HLS] master=%q pick=%q", srcURL, out)
+	return out, nil
+}
+
+func (s *hlsSource) Meta(ctx context.Context, srcURL string) (*models.MediaMeta, error) {
+	media, err := s.Resolve(ctx, srcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	pl, err := s.fetch(ctx, media)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &models.MediaMeta{IsLive: true, LiveStatus: "is_live"}
+	if pl.ended {
+		meta.IsLive = false
+		meta.LiveStatus = "not_live"
+		meta.DurationSec = int(pl.duration + 0.999)
+	}
+	return meta, nil
+}
+
+func (s *hlsSource) Invalidate(string) {}
+
+func (s *hlsSource) fetch(ctx context.Context, playlistURL string) (*hlsPlaylist, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, playlistURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("hls fetch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("hls fetch: status %d", resp.StatusCode)
+	}
+
+	return parsePlaylist(io.LimitReader(resp.Body, maxPlaylistBytes))
+}
+
+func parsePlaylist(r io.Reader) (*hlsPlaylist, error) {
+	pl := &hlsPlaylist{}
+	minBandwidth := -1
+	pendingBandwidth := -1
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64<<10), maxPlaylistBytes)
+
+	first := true
+	for sc.Scan() {
+		ln := strings.TrimSpace(sc.Text())
+		if ln == "" {
+			continue
+		}
+		if first {
+			if ln != "#EXTM3U" {
+				return nil, fmt.Errorf("not an m3u8 playlist")
+			}
+			first = false
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(ln, "#EXT-X-STREAM-INF:"):
+			pl.master = true
+			attrs := parseAttrs(strings.TrimPrefix(ln, "#EXT-X-STREAM-INF:"))
+			pendingBandwidth, _ = strconv.Atoi(attrs["BANDWIDTH"])
+
+		case strings.HasPrefix(ln, "#EXT-X-MEDIA:"):
+			attrs := parseAttrs(strings.TrimPrefix(ln, "#EXT-X-MEDIA:"))
+			if attrs["TYPE"] == "AUDIO" && attrs["URI"] != "" &&
+				(pl.audio == "" || attrs["DEFAULT"] == "YES") {
+				pl.audio = attrs["URI"]
+			}
+
+		case strings.HasPrefix(ln, "#EXTINF:"):
+			v := strings.TrimPrefix(ln, "#EXTINF:")
+			if i := strings.IndexByte(v, ','); i >= 0 {
+				v = v[:i]
+			}
+			if d, err := strconv.ParseFloat(v, 64); err == nil {
+				pl.duration += d
+			}
+
+		case ln == "#EXT-X-ENDLIST":
+			pl.ended = true
+
+		case !strings.HasPrefix(ln, "#") && pendingBandwidth >= 0:
+			// URI варианта — строка сразу после #EXT-X-STREAM-INF
+			if minBandwidth < 0 || pendingBandwidth < minBandwidth {
+				minBandwidth = pendingBandwidth
+				pl.variant = ln
+			}
+			pendingBandwidth = -1
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("hls parse: %w", err)
+	}
+	if first {
+		return nil, fmt.Errorf("empty playlist")
+	}
+	return pl, nil
+}
+
+// parseAttrs — KEY=VALUE,KEY="VALUE, с запятой"
+func parseAttrs(s string) map[string]string {
+	out := make(map[string]string)
+
+	for s != "" {
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.TrimSpace(s[:eq])
+		s = s[eq+1:]
+
+		var val string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				val, s = s[1:], ""
+			} else {
+				val, s = s[1:end+1], s[end+2:]
+			}
+		} else if comma := strings.IndexByte(s, ','); comma >= 0 {
+			val, s = s[:comma], s[comma:]
+		} else {
+			val, s = s, ""
+		}
+		s = strings.TrimPrefix(s, ",")
+
+		out[key] = val
+	}
+	return out
+}
+
+func resolveRef(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return b.ResolveReference(r).String(), nil
+}
@@ -0,0 +1,174 @@
+package stations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+// ISO 639-1 → варианты тегов ISO 639-2, которые встречаются в контейнерах
+var trackLangAliases = map[string][]string{
+	"ru": {"rus"},
+	"en": {"eng"},
+	"de": {"deu", "ger"},
+	"fr": {"fra", "fre"},
+	"es": {"spa"},
+	"it": {"ita"},
+	"pt": {"por"},
+	"uk": {"ukr"},
+	"be": {"bel"},
+	"kk": {"kaz"},
+	"zh": {"zho", "chi"},
+	"ja": {"jpn"},
+	"ko": {"kor"},
+	"ar": {"ara"},
+	"tr": {"tur"},
+}
+
+type ffprobeTracks struct {
+	Streams []struct {
+		CodecName     string            `json:"codec_name"`
+		Channels      int               `json:"channels"`
+		ChannelLayout string            `json:"channel_layout"`
+		SampleRate    string            `json:"sample_rate"`
+		Tags          map[string]string `json:"tags"`
+		Disposition   map[string]int    `json:"disposition"`
+	} `json:"streams"`
+}
+
+// ProbeTracks — аудиодорожки входа ffmpeg (файл, HLS, RTMP, ...)
+func ProbeTracks(ctx context.Context, in string) ([]models.AudioTrack, error) {
+	out, err := exec.CommandContext(ctx,
+		"ffprobe",
+		"-v", "error",
+		"-select_streams", "a",
+		"-show_entries", "stream=codec_name,channels,channel_layout,sample_rate:stream_tags=language,title:stream_disposition=default",
+		"-of", "json",
+		in,
+	).Output()
+	if err != nil {
+		return nil, probeError("ffprobe tracks", err)
+	}
+
+	var probe ffprobeTracks
+	if err := json.Unmarshal(out, &probe); err != nil {
+		return nil, fmt.Errorf("ffprobe tracks decode: %w", err)
+	}
+	if len(probe.Streams) == 0 {
+		return nil, ErrNoAudio
+	}
+
+	tracks := make([]models.AudioTrack, 0, len(probe.Streams))
+	for i, st := range probe.Streams {
+		rate, _ := strconv.Atoi(st.SampleRate)
+		tracks = append(tracks, models.AudioTrack{
+			Index:      i,
+			Codec:      st.CodecName,
+			Language:   st.Tags["language"],
+			Title:      st.Tags["title"],
+			Channels:   st.Channels,
+			Layout:     st.ChannelLayout,
+			SampleRate: rate,
+			Default:    st.Disposition["default"] == 1,
+		})
+	}
+	return tracks, nil
+}
+
+// SelectTrack — выбор по номеру или языку → номер дорожки и проверенный канал.
+// tracks пусто (проба не удалась) → номер и канал передаются как есть
+func SelectTrack(tracks []models.AudioTrack, sel models.AudioSelection) (models.AudioSelection, error) {
+	out := models.AudioSelection{Track: sel.Track, Channel: sel.Channel}
+
+	switch sel.Channel {
+	case "", models.ChannelLeft, models.ChannelRight:
+	default:
+		return out, fmt.Errorf("%w: channel must be left or right", ports.ErrTrackNotFound)
+	}
+
+	if out.Track == nil && sel.Language != "" {
+		for i := range tracks {
+			if trackLangMatches(tracks[i].Language, sel.Language) {
+				idx := tracks[i].Index
+				out.Track = &idx
+				break
+			}
+		}
+		if out.Track == nil {
+			return out, fmt.Errorf("%w: no track with language %q", ports.ErrTrackNotFound, sel.Language)
+		}
+	}
+
+	if len(tracks) == 0 {
+		return out, nil
+	}
+
+	track := defaultTrack(tracks)
+	if out.Track != nil {
+		if *out.Track < 0 || *out.Track >= len(tracks) {
+			return out, fmt.Errorf("%w: track %d of %d", ports.ErrTrackNotFound, *out.Track, len(tracks))
+		}
+		track = tracks[*out.Track]
+	}
+
+	if out.Channel != "" && track.Channels < 2 {
+		return out, fmt.Errorf("%w: track %d is mono", ports.ErrTrackNotFound, track.Index)
+	}
+	return out, nil
+}
+
+// defaultTrack — то, что ffmpeg возьмёт без -map: отмеченная default или первая
+func defaultTrack(tracks []models.AudioTrack) models.AudioTrack {
+	for _, t := range tracks {
+		if t.Default {
+			return t
+		}
+	}
+	return tracks[0]
+}
+
+func trackLangMatches(tag, want string) bool {
+	tag, want = strings.ToLower(tag), strings.ToLower(want)
+	if tag == "" {
+		return false
+	}
+	if tag == want {
+		return true
+	}
+	for _, alias := range trackLangAliases[want] {
+		if tag == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// audioArgs — -map / pan для ffmpeg после -i; выбор уже прошёл SelectTrack
+func audioArgs(sel models.AudioSelection) []string {
+	var args []string
+	if sel.Track != nil {
+		args = append(args, "-map", fmt.Sprintf("0:a:%d", *sel.Track))
+	}
+
+	if f := panFilter(sel.Channel); f != "" {
+		args = append(args, "-af", f)
+	}
+	return args
+}
+
+// panFilter — один канал стерео вместо даунмикса
+func panFilter(channel string) string {
+	switch channel {
+	case models.ChannelLeft:
+		return "pan=mono|c0=c0"
+	case models.ChannelRight:
+		return "pan=mono|c0=c1"
+	}
+	return ""
+}
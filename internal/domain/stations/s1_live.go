@@ -0,0 +1,106 @@
+package stations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+// сколько последних записей канала / плейлиста просматривать
+const liveCheckDepth = 15
+
+// yt-dlp на /live без эфира падает с такими сообщениями — это «не в эфире», не ошибка
+var notLiveMarkers = []string{
+	"not currently live",
+	"this live event will begin",
+	"premieres in",
+	"does not have a live",
+}
+
+// S1LiveCheck — идёт ли сейчас эфир на канале / в плейлисте
+type S1LiveCheck struct {
+	cookies CookieSource
+}
+
+func NewS1LiveCheck(cookies CookieSource) *S1LiveCheck {
+	return &S1LiveCheck{cookies: cookies}
+}
+
+type ytdlpLiveEntry struct {
+	ID         string           `json:"id"`
+	URL        string           `json:"url"`
+	WebpageURL string           `json:"webpage_url"`
+	Title      string           `json:"title"`
+	IsLive     bool             `json:"is_live"`
+	LiveStatus string           `json:"live_status"`
+	Entries    []ytdlpLiveEntry `json:"entries"`
+}
+
+// Run — текущий эфир или nil, если канал не в эфире
+func (s *S1LiveCheck) Run(ctx context.Context, pageURL string) (*models.LiveBroadcast, error) {
+	args := []string{
+		"--flat-playlist",
+		"--playlist-end", fmt.Sprint(liveCheckDepth),
+		"--skip-download",
+		"-J",
+	}
+	if f := s.cookies.CookieFile(pageURL); f != "" {
+		args = append(args, "--cookies", f)
+	}
+	args = append(args, pageURL)
+
+	cmd := exec.CommandContext(ctx, "yt-dlp", args...)
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			stderr := strings.ToLower(string(ee.Stderr))
+			for _, m := range notLiveMarkers {
+				if strings.Contains(stderr, m) {
+					return nil, nil
+				}
+			}
+			log.Printf("[S1-LIVE][STDERR] %s", trim(string(ee.Stderr), 280))
+			if cerr := classifyStderr("yt-dlp", string(ee.Stderr)); cerr != nil && ctx.Err() == nil {
+				return nil, cerr
+			}
+		}
+		return nil, fmt.Errorf("yt-dlp live check: %w", err)
+	}
+
+	var info ytdlpLiveEntry
+	if err := json.Unmarshal(out, &info); err != nil {
+		return nil, fmt.Errorf("yt-dlp live check decode: %w", err)
+	}
+
+	b := findLive(&info)
+	if b != nil {
+		log.Printf("[S1-LIVE][ON] page=%q id=%s title=%q", pageURL, b.ID, trim(b.Title, 80))
+	}
+	return b, nil
+}
+
+// findLive — сама страница (/live, ссылка на эфир) или первая live-запись
+// в entries (вкладки канала вложены: канал → streams → видео)
+func findLive(e *ytdlpLiveEntry) *models.LiveBroadcast {
+	if e.IsLive || e.LiveStatus == "is_live" {
+		u := e.WebpageURL
+		if u == "" {
+			u = e.URL
+		}
+		if e.ID != "" && u != "" {
+			return &models.LiveBroadcast{ID: e.ID, URL: u, Title: e.Title}
+		}
+	}
+
+	for i := range e.Entries {
+		if b := findLive(&e.Entries[i]); b != nil {
+			return b
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package stations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os/exec"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+// S1Metadata — что за источник: название, канал, длительность, live или запись
+type S1Metadata struct {
+	cookies CookieSource
+}
+
+func NewS1Metadata(cookies CookieSource) *S1Metadata {
+	return &S1Metadata{cookies: cookies}
+}
+
+type ytdlpInfo struct {
+	Title            string  `json:"title"`
+	Uploader         string  `json:"uploader"`
+	Channel          string  `json:"channel"`
+	Duration         float64 `json:"duration"`
+	IsLive           bool    `json:"is_live"`
+	LiveStatus       string  `json:"live_status"`
+	Thumbnail        string  `json:"thumbnail"`
+	ReleaseTimestamp int64   `json:"release_timestamp"`
+	Timestamp        int64   `json:"timestamp"`
+	UploadDate       string  `json:"upload_date"` // YYYYMMDD
+}
+
+func (s *S1Metadata) Run(ctx context.Context, pageURL string) (*models.MediaMeta, error) {
+	log.Printf("[S1-META][START] page=%q", pageURL)
+
+	args := []string{
+		"--no-playlist",
+		"--skip-download",
+		"-J",
+	}
+	if f := s.cookies.CookieFile(pageURL); f != "" {
+		args = append(args, "--cookies", f)
+	}
+	args = append(args, pageURL)
+
+	cmd := exec.CommandContext(ctx, "yt-dlp", args...)
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && ctx.Err() == nil {
+			log.Printf("[S1-META][STDERR] %s", trim(string(ee.Stderr), 280))
+			if cerr := classifyStderr("yt-dlp", string(ee.Stderr)); cerr != nil {
+				return nil, cerr
+			}
+		}
+		return nil, fmt.Errorf("yt-dlp -J: %w", err)
+	}
+
+	var info ytdlpInfo
+	if err := json.Unmarshal(out, &info); err != nil {
+		return nil, fmt.Errorf("yt-dlp -J decode: %w", err)
+	}
+
+	meta := &models.MediaMeta{
+		Title:        info.Title,
+		Uploader:     info.Uploader,
+		DurationSec:  int(info.Duration),
+		IsLive:       info.IsLive || info.LiveStatus == "is_live",
+		LiveStatus:   info.LiveStatus,
+		ThumbnailURL: info.Thumbnail,
+		PublishedAt:  info.publishedAt(),
+	}
+	if meta.Uploader == "" {
+		meta.Uploader = info.Channel
+	}
+
+	log.Printf("[S1-META][OK] title=%q live=%v status=%s dur=%ds",
+		trim(meta.Title, 80), meta.IsLive, meta.LiveStatus, meta.DurationSec)
+	return meta, nil
+}
+
+func (i ytdlpInfo) publishedAt() *time.Time {
+	var t time.Time
+	switch {
+	case i.ReleaseTimestamp > 0:
+		t = time.Unix(i.ReleaseTimestamp, 0)
+	case i.Timestamp > 0:
+		t = time.Unix(i.Timestamp, 0)
+	case i.UploadDate != "":
+		parsed, err := time.Parse("20060102", i.UploadDate)
+		if err != nil {
+			return nil
+		}
+		t = parsed
+	default:
+		return nil
+	}
+	return &t
+}
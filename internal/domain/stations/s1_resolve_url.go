@@ -4,29 +4,261 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
 	"os/exec"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
+const (
+	s1FallbackTTL   = 10 * time.Minute // если в URL нет expire
+	s1MaxTTL        = 6 * time.Hour
+	s1RefreshBefore = 2 * time.Minute  // обновляем заранее, в фоне
+	s1ResolveTO     = 60 * time.Second // общий вызов yt-dlp не зависит от ctx ждущих
+	s1SweepEvery    = 10 * time.Minute // чистка протухших записей (эфиры кончаются, URL не повторяются)
+)
+
+type s1CacheEntry struct {
+	url        string
+	expires    time.Time
+	refreshing bool
+}
+
+type s1Call struct {
+	done chan struct{}
+	url  string
+	err  error
+}
+
+// s1Pending — идущие resolve страницы (общий и фоновые refresh).
+// gen +1 на Invalidate: начатый раньше в кэш не пишет. Запись живёт,
+// пока n > 0 — потом сбрасывать gen безопасно, его никто не держит
+type s1Pending struct {
+	n   int
+	gen uint64
+}
+
+// CookieSource — какой cookies.txt отдать yt-dlp для страницы
+type CookieSource interface {
+	CookieFile(pageURL string) string
+}
+
+// StaticCookies — один файл на всё (YTDLP_COOKIES_FILE); пусто → без куки
+type StaticCookies string
+
+func (c StaticCookies) CookieFile(string) string { return string(c) }
+
 type S1ResolveURL struct {
-	cookieFile string
+	cookies CookieSource
+
+	// pageURL → прямой URL потока; yt-dlp зовём только когда он протух
+	mu       sync.Mutex
+	cache    map[string]*s1CacheEntry
+	inflight map[string]*s1Call
+	pending  map[string]*s1Pending
+	swept    time.Time
 }
 
-func NewS1ResolveURL(cookieFile string) *S1ResolveURL {
-	return &S1ResolveURL{cookieFile: cookieFile}
+func NewS1ResolveURL(cookies CookieSource) *S1ResolveURL {
+	return &S1ResolveURL{
+		cookies:  cookies,
+		cache:    make(map[string]*s1CacheEntry),
+		inflight: make(map[string]*s1Call),
+		pending:  make(map[string]*s1Pending),
+		swept:    time.Now(),
+	}
 }
 
 func (s *S1ResolveURL) Run(ctx context.Context, pageURL string) (string, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	if e, ok := s.cache[pageURL]; ok && now.Before(e.expires) {
+		if !e.refreshing && now.After(e.expires.Add(-s1RefreshBefore)) {
+			e.refreshing = true
+			go s.refresh(pageURL, s.begin(pageURL))
+		}
+		s.mu.Unlock()
+		log.Printf("[S1][CACHE] page=%q ttl=%s", pageURL, e.expires.Sub(now).Round(time.Second))
+		return e.url, nil
+	}
+	delete(s.cache, pageURL)
+	s.mu.Unlock()
+
+	return s.resolveShared(ctx, pageURL)
+}
+
+// Invalidate — ffmpeg получил 403/404: закэшированный URL больше не годится
+func (s *S1ResolveURL) Invalidate(pageURL string) {
+	s.mu.Lock()
+	delete(s.cache, pageURL)
+	if p, ok := s.pending[pageURL]; ok {
+		p.gen++
+	}
+	s.mu.Unlock()
+	log.Printf("[S1][INVALIDATE] page=%q", pageURL)
+}
+
+// resolveShared — параллельные тики ждут один вызов yt-dlp, а не плодят свои.
+// Вызов идёт в своей горутине со своим таймаутом: отмена первого
+// (например, его сессию остановили) не роняет остальных
+func (s *S1ResolveURL) resolveShared(ctx context.Context, pageURL string) (string, error) {
+	s.mu.Lock()
+	call, ok := s.inflight[pageURL]
+	if !ok {
+		call = &s1Call{done: make(chan struct{})}
+		s.inflight[pageURL] = call
+		go s.runCall(context.WithoutCancel(ctx), pageURL, call, s.begin(pageURL))
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.url, call.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (s *S1ResolveURL) runCall(ctx context.Context, pageURL string, call *s1Call, gen uint64) {
+	ctx, cancel := context.WithTimeout(ctx, s1ResolveTO)
+	defer cancel()
+
+	call.url, call.err = s.resolve(ctx, pageURL)
+
+	s.mu.Lock()
+	delete(s.inflight, pageURL)
+	if s.end(pageURL, gen) && call.err == nil {
+		s.store(pageURL, call.url)
+	}
+	s.mu.Unlock()
+	close(call.done)
+}
+
+// refresh — gen (begin) взят в Run под s.mu вместе с решением обновлять
+func (s *S1ResolveURL) refresh(pageURL string, gen uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), s1ResolveTO)
+	defer cancel()
+
+	u, err := s.resolve(ctx, pageURL)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.end(pageURL, gen) {
+		// пока ходили в yt-dlp, URL отклонили — не воскрешаем запись
+		log.Printf("[S1][REFRESH][DROP] page=%q invalidated", pageURL)
+		return
+	}
+	if err != nil {
+		log.Printf("[S1][REFRESH][ERR] page=%q err=%v", pageURL, err)
+		if e, ok := s.cache[pageURL]; ok {
+			e.refreshing = false
+		}
+		return
+	}
+
+	s.store(pageURL, u)
+	log.Printf("[S1][REFRESH][OK] page=%q", pageURL)
+}
+
+// begin / end — учёт идущих resolve; под s.mu. end: false — URL
+// отклонили (Invalidate) после begin, результат в кэш не пишется
+func (s *S1ResolveURL) begin(pageURL string) uint64 {
+	p, ok := s.pending[pageURL]
+	if !ok {
+		p = &s1Pending{}
+		s.pending[pageURL] = p
+	}
+	p.n++
+	return p.gen
+}
+
+func (s *S1ResolveURL) end(pageURL string, gen uint64) bool {
+	p := s.pending[pageURL]
+	fresh := p.gen == gen
+	if p.n--; p.n == 0 {
+		delete(s.pending, pageURL)
+	}
+	return fresh
+}
+
+// store — вызывать под s.mu; заодно раз в s1SweepEvery выбрасывает
+// протухшие записи страниц, к которым больше не обращаются
+func (s *S1ResolveURL) store(pageURL, streamURL string) {
+	now := time.Now()
+	s.cache[pageURL] = &s1CacheEntry{
+		url:     streamURL,
+		expires: streamExpiry(streamURL, now),
+	}
+
+	if now.Sub(s.swept) < s1SweepEvery {
+		return
+	}
+	s.swept = now
+	for k, e := range s.cache {
+		if !now.Before(e.expires) {
+			delete(s.cache, k)
+		}
+	}
+}
+
+// streamExpiry — googlevideo: ?expire=<unix>, HLS-манифест: /expire/<unix>/
+func streamExpiry(streamURL string, now time.Time) time.Time {
+	fallback := now.Add(s1FallbackTTL)
+
+	u, err := url.Parse(streamURL)
+	if err != nil {
+		return fallback
+	}
+
+	raw := u.Query().Get("expire")
+	if raw == "" {
+		parts := strings.Split(u.Path, "/")
+		for i := 0; i+1 < len(parts); i++ {
+			if parts[i] == "expire" {
+				raw = parts[i+1]
+				break
+			}
+		}
+	}
+
+	sec, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return fallback
+	}
+
+	exp := time.Unix(sec, 0)
+	switch {
+	case !exp.After(now):
+		return fallback
+	case exp.After(now.Add(s1MaxTTL)):
+		return now.Add(s1MaxTTL)
+	}
+	return exp
+}
+
+func (s *S1ResolveURL) resolve(ctx context.Context, pageURL string) (string, error) {
+	return ResolveWithCookies(ctx, pageURL, s.cookies.CookieFile(pageURL))
+}
+
+// ResolveWithCookies — yt-dlp -g без кэша; им же проверяются новые cookie jar
+func ResolveWithCookies(ctx context.Context, pageURL, cookieFile string) (string, error) {
 	log.Printf("[S1][START] page=%q", pageURL)
 
+	// без -f первая строка -g у записи YouTube — DASH только с видео;
+	// у live аудио отдельно нет — /best берёт общий HLS
 	args := []string{
 		"--no-playlist",
+		"-f", "bestaudio/best",
 		"-g",
 	}
 
 	// если куки есть → добавляем
-	if s.cookieFile != "" {
-		args = append(args, "--cookies", s.cookieFile)
+	if cookieFile != "" {
+		args = append(args, "--cookies", cookieFile)
 	}
 
 	args = append(args, pageURL)
@@ -45,6 +277,9 @@ func (s *S1ResolveURL) Run(ctx context.Context, pageURL string) (string, error)
 		log.Printf("[S1][ERR] empty output")
 		return "", fmt.Errorf("empty yt-dlp output")
 	}
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
 
 	// ищем первую http-строку
 	for _, ln := range strings.Split(raw, "\n") {
@@ -55,6 +290,11 @@ func (s *S1ResolveURL) Run(ctx context.Context, pageURL string) (string, error)
 		}
 	}
 
+	if cerr := classifyStderr("yt-dlp", raw); cerr != nil {
+		log.Printf("[S1][ERR] %v", cerr)
+		return "", cerr
+	}
+
 	log.Printf("[S1][ERR] parsed url empty")
-	return "", fmt.Errorf("parsed url empty")
+	return "", fmt.Errorf("parsed url empty: %s", trim(raw, 280))
 }
@@ -0,0 +1,100 @@
+package stations
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+// FilterNone — без предобработки (как раньше: только даунмикс и ресемплинг)
+const FilterNone = "none"
+
+// FilterPresets — цепочки ffmpeg -af для речи; применяются уже к моно 16 кГц.
+// AUDIO_FILTER_CUSTOM — своя цепочка под именем "custom"
+var FilterPresets = map[string]string{
+	FilterNone: "",
+	// лёгкая чистка: гул и «дыхание» микрофона
+	"speech": "highpass=f=80,lowpass=f=7600",
+	// улица, выездные записи: шумодав + компрессор + выравнивание громкости
+	"field": "highpass=f=100,afftdn=nf=-25,acompressor=threshold=-20dB:ratio=3:attack=5:release=100,dynaudnorm=f=200:g=11",
+	// тихие микрофоны (парламент, зал): сильная компрессия и подъём
+	"quiet": "highpass=f=80,acompressor=threshold=-32dB:ratio=4:attack=5:release=150:makeup=8,dynaudnorm=f=150:g=15:m=20",
+	// EBU R128 на окно; громкость ровная от окна к окну
+	"loudnorm": "highpass=f=80,loudnorm=I=-18:TP=-2:LRA=11",
+}
+
+// FilterChain — цепочка пресета; "" → none
+func FilterChain(preset string) (string, error) {
+	if preset == "" {
+		return "", nil
+	}
+	if preset == "custom" {
+		if chain := os.Getenv("AUDIO_FILTER_CUSTOM"); chain != "" {
+			return chain, nil
+		}
+	}
+	chain, ok := FilterPresets[preset]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ports.ErrFilterUnknown, preset)
+	}
+	return chain, nil
+}
+
+// FilterPresetNames — для API
+func FilterPresetNames() []string {
+	names := make([]string, 0, len(FilterPresets)+1)
+	for name := range FilterPresets {
+		names = append(names, name)
+	}
+	if os.Getenv("AUDIO_FILTER_CUSTOM") != "" {
+		names = append(names, "custom")
+	}
+	sort.Strings(names)
+	return names
+}
+
+// минимальный уровень: тишина в 16 бит
+const silenceDB = -96.0
+
+// PCMRMSdB — RMS s16le в dBFS
+func PCMRMSdB(pcm []byte) float64 {
+	n := len(pcm) / 2
+	if n == 0 {
+		return silenceDB
+	}
+
+	var sum float64
+	for i := 0; i < n; i++ {
+		v := float64(int16(binary.LittleEndian.Uint16(pcm[2*i:])))
+		sum += v * v
+	}
+
+	rms := math.Sqrt(sum / float64(n))
+	if rms == 0 {
+		return silenceDB
+	}
+	return math.Max(20*math.Log10(rms/32768), silenceDB)
+}
+
+// filterGraph — -filter_complex: выбранная дорожка / канал → моно 16 кГц →
+// [raw] для замера «до» и [out] после цепочки
+func filterGraph(chain string, sel models.AudioSelection) string {
+	in := "[0:a]"
+	if sel.Track != nil {
+		in = fmt.Sprintf("[0:a:%d]", *sel.Track)
+	}
+
+	pan := ""
+	if f := panFilter(sel.Channel); f != "" {
+		pan = f + ","
+	}
+
+	return in + pan +
+		"aresample=16000,aformat=sample_fmts=s16:channel_layouts=mono,asplit=2[raw][f];" +
+		"[f]" + chain + ",aresample=16000[out]"
+}
@@ -2,37 +2,99 @@ package stations
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"os"
 	"os/exec"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/Vovarama1992/journalist/internal/models"
 )
 
 const maxS2ErrPreview = 180
 
+// ErrStreamRejected — источник ответил 403/404: прямой URL протух,
+// его надо заново получить через S1
+var ErrStreamRejected = errors.New("stream url rejected")
+
 type S2GrabPCM struct{}
 
 func NewS2GrabPCM() *S2GrabPCM {
 	return &S2GrabPCM{}
 }
 
-func (s *S2GrabPCM) Run(ctx context.Context, audioURL string) ([]byte, error) {
+// GrabOptions — параметры окна захвата
+type GrabOptions struct {
+	// Offset — с какой позиции читать (только запись / VOD); 0 → с текущей
+	Offset time.Duration
+	// Audio — дорожка / канал (после SelectTrack); пусто → даунмикс дорожки по умолчанию
+	Audio models.AudioSelection
+	// Filter — цепочка -af (FilterChain); пусто → без предобработки
+	Filter string
+}
+
+// GrabResult — PCM окна и уровни до / после фильтров (dBFS)
+type GrabResult struct {
+	PCM       []byte
+	RMSBefore float64
+	RMSAfter  float64
+}
+
+// WindowSec — длина окна захвата
+const WindowSec = 20
+
+func (s *S2GrabPCM) Run(ctx context.Context, audioURL string, opts GrabOptions) (*GrabResult, error) {
 	start := time.Now()
-	log.Printf("[S2][START] url=%s", audioURL)
-
-	cmd := exec.CommandContext(
-		ctx,
-		"ffmpeg",
-		"-loglevel", "error",
-		"-i", audioURL,
-		"-vn",
-		"-ac", "1",
-		"-ar", "16000",
-		"-t", "20", // <<< УВЕЛИЧЕННОЕ ОКНО
-		"-f", "s16le",
-		"pipe:1",
-	)
+	log.Printf("[S2][START] url=%s offset=%s filter=%t", audioURL, opts.Offset, opts.Filter != "")
+
+	args := []string{"-loglevel", "error"}
+	if opts.Offset > 0 {
+		// -ss до -i: быстрый seek по источнику
+		args = append(args, "-ss", fmt.Sprintf("%.3f", opts.Offset.Seconds()))
+	}
+	args = append(args, "-i", audioURL)
+
+	// с фильтрами второй выход — тот же звук до цепочки, в pipe:3, для замера
+	var rawR, rawW *os.File
+	if opts.Filter != "" {
+		var err error
+		rawR, rawW, err = os.Pipe()
+		if err != nil {
+			return nil, fmt.Errorf("[S2] raw pipe: %w", err)
+		}
+		defer rawR.Close()
+
+		args = append(args,
+			"-filter_complex", filterGraph(opts.Filter, opts.Audio),
+			"-map", "[out]",
+			"-t", strconv.Itoa(WindowSec),
+			"-f", "s16le",
+			"pipe:1",
+			"-map", "[raw]",
+			"-t", strconv.Itoa(WindowSec),
+			"-f", "s16le",
+			"pipe:3",
+		)
+	} else {
+		args = append(args, audioArgs(opts.Audio)...)
+		args = append(args,
+			"-vn",
+			"-ac", "1",
+			"-ar", "16000",
+			"-t", strconv.Itoa(WindowSec), // <<< УВЕЛИЧЕННОЕ ОКНО
+			"-f", "s16le",
+			"pipe:1",
+		)
+	}
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	if rawW != nil {
+		cmd.ExtraFiles = []*os.File{rawW} // fd 3
+	}
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -40,17 +102,32 @@ func (s *S2GrabPCM) Run(ctx context.Context, audioURL string) ([]byte, error) {
 	}
 
 	stderr, _ := cmd.StderrPipe()
+	stderrDone := make(chan string, 1)
 	go func() {
 		b, _ := io.ReadAll(stderr)
 		if len(b) > 0 {
 			log.Printf("[S2][STDERR] %s", string(b))
 		}
+		stderrDone <- string(b)
 	}()
 
 	if err := cmd.Start(); err != nil {
+		if rawW != nil {
+			rawW.Close()
+		}
 		return nil, fmt.Errorf("[S2] ffmpeg start: %w", err)
 	}
 
+	// «до» читаем параллельно: иначе ffmpeg встанет на полном pipe:3
+	rawDone := make(chan []byte, 1)
+	if rawW != nil {
+		rawW.Close()
+		go func() {
+			b, _ := io.ReadAll(rawR)
+			rawDone <- b
+		}()
+	}
+
 	var pcm []byte
 	buf := make([]byte, 4096)
 
@@ -68,20 +145,43 @@ func (s *S2GrabPCM) Run(ctx context.Context, audioURL string) ([]byte, error) {
 		}
 	}
 
+	errOut := <-stderrDone
 	_ = cmd.Wait()
 
 	dur := time.Since(start)
 	if len(pcm) == 0 {
 		log.Printf("[S2][EMPTY] dur=%s", dur)
-		return pcm, nil
+		if isHTTPRejected(errOut) {
+			return nil, fmt.Errorf("[S2] %w: %s", ErrStreamRejected, trim(errOut, maxS2ErrPreview))
+		}
+		// пустой stderr — поток просто молчит / кончился
+		if cerr := classifyStderr("ffmpeg", errOut); cerr != nil && ctx.Err() == nil {
+			return nil, cerr
+		}
+		return &GrabResult{}, nil
+	}
+
+	res := &GrabResult{PCM: pcm, RMSAfter: PCMRMSdB(pcm)}
+	res.RMSBefore = res.RMSAfter
+	if rawW != nil {
+		res.RMSBefore = PCMRMSdB(<-rawDone)
 	}
 
 	log.Printf(
-		"[S2][OK] bytes=%d approx_sec=%.1f dur=%s",
+		"[S2][OK] bytes=%d approx_sec=%.1f rms=%.1f→%.1fdB dur=%s",
 		len(pcm),
 		float64(len(pcm))/2/16000,
+		res.RMSBefore,
+		res.RMSAfter,
 		dur,
 	)
 
-	return pcm, nil
+	return res, nil
+}
+
+func isHTTPRejected(stderr string) bool {
+	return strings.Contains(stderr, "403 Forbidden") ||
+		strings.Contains(stderr, "404 Not Found") ||
+		strings.Contains(stderr, "HTTP error 403") ||
+		strings.Contains(stderr, "HTTP error 404")
 }
@@ -0,0 +1,159 @@
+package stations
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+const (
+	// RecordBitrate — MP3 CBR: байт ↔ время пересчитываются без индекса
+	RecordBitrate     = 64000
+	RecordBytesPerMs  = RecordBitrate / 8 / 1000
+	recordSegmentSec  = 60
+	maxRecordErrBytes = 280
+	// после отмены ctx ffmpeg получает SIGINT и дописывает последний сегмент
+	recordStopWait = 10 * time.Second
+)
+
+// S2Record — непрерывная запись эфира в MP3-сегменты (параллельно окнам S2)
+type S2Record struct{}
+
+func NewS2Record() *S2Record { return &S2Record{} }
+
+// Run — пишет dir/seg_NNNNNN.mp3 начиная с номера start; onSegment зовётся
+// на каждый закрытый сегмент, в т.ч. последний неполный. Возврат — когда
+// поток кончился, URL протух или ctx отменён. audio — та же дорожка, что у S2
+func (s *S2Record) Run(
+	ctx context.Context,
+	audioURL string,
+	audio models.AudioSelection,
+	dir string,
+	start int,
+	onSegment func(seq int, path string),
+) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("[S2-REC] dir: %w", err)
+	}
+
+	log.Printf("[S2-REC][START] url=%s seq=%d", audioURL, start)
+
+	args := []string{"-loglevel", "error", "-i", audioURL}
+	args = append(args, audioArgs(audio)...)
+	args = append(args,
+		"-vn",
+		"-ac", "1",
+		"-ar", "44100",
+		"-c:a", "libmp3lame",
+		"-b:a", strconv.Itoa(RecordBitrate),
+		"-f", "segment",
+		"-segment_time", strconv.Itoa(recordSegmentSec),
+		"-segment_format", "mp3",
+		// без Xing/ID3: сегменты склеиваются в один поток байт-в-байт
+		"-segment_format_options", "write_xing=0:id3v2_version=0",
+		"-segment_start_number", strconv.Itoa(start),
+		"-segment_list", "pipe:1",
+		"-segment_list_type", "flat",
+		"-reset_timestamps", "1",
+		filepath.Join(dir, "seg_%06d.mp3"),
+	)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	// SIGKILL оборвал бы последний сегмент: ни файла целиком, ни строки в segment_list
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = recordStopWait
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("[S2-REC] stdout pipe: %w", err)
+	}
+
+	var stderr strings.Builder
+	cmd.Stderr = &limitedWriter{w: &stderr, n: maxRecordErrBytes}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("[S2-REC] ffmpeg start: %w", err)
+	}
+
+	// segment_list: имя файла строкой, когда сегмент закрыт
+	reported := make(map[int]bool)
+	sc := bufio.NewScanner(stdout)
+	for sc.Scan() {
+		name := strings.TrimSpace(sc.Text())
+		if name == "" {
+			continue
+		}
+
+		seq, err := segmentSeq(name)
+		if err != nil {
+			log.Printf("[S2-REC][WARN] bad segment name %q", name)
+			continue
+		}
+		reported[seq] = true
+		onSegment(seq, filepath.Join(dir, filepath.Base(name)))
+	}
+
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		// ffmpeg не успел за WaitDelay — недописанный сегмент всё равно сохраняем
+		flushSegments(dir, start, reported, onSegment)
+		log.Printf("[S2-REC][STOP] url=%s", audioURL)
+		return nil
+	}
+	if err != nil {
+		if isHTTPRejected(stderr.String()) {
+			return fmt.Errorf("[S2-REC] %w: %s", ErrStreamRejected, stderr.String())
+		}
+		if cerr := classifyStderr("ffmpeg", stderr.String()); cerr != nil {
+			return cerr
+		}
+		return fmt.Errorf("[S2-REC] ffmpeg: %w: %s", err, stderr.String())
+	}
+	return nil
+}
+
+// flushSegments — сегменты на диске, которых не было в segment_list, по порядку
+func flushSegments(dir string, start int, reported map[int]bool, onSegment func(seq int, path string)) {
+	paths, _ := filepath.Glob(filepath.Join(dir, "seg_*.mp3"))
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		seq, err := segmentSeq(path)
+		if err != nil || seq < start || reported[seq] {
+			continue
+		}
+		log.Printf("[S2-REC][FLUSH] seq=%d", seq)
+		onSegment(seq, path)
+	}
+}
+
+func segmentSeq(name string) (int, error) {
+	base := strings.TrimSuffix(filepath.Base(name), ".mp3")
+	return strconv.Atoi(strings.TrimPrefix(base, "seg_"))
+}
+
+// limitedWriter — начало stderr ffmpeg для текста ошибки
+type limitedWriter struct {
+	w io.Writer
+	n int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.n > 0 {
+		k := min(len(p), l.n)
+		_, _ = l.w.Write(p[:k])
+		l.n -= k
+	}
+	return len(p), nil
+}
@@ -16,10 +16,10 @@ func NewS4WAVtoText(stt ports.STTService) *S4WAVtoText {
 	return &S4WAVtoText{stt: stt}
 }
 
-func (s *S4WAVtoText) Run(ctx context.Context, wav []byte) (string, error) {
+func (s *S4WAVtoText) Run(ctx context.Context, wav []byte, opts ports.RecognizeOptions) (string, error) {
 	log.Printf("[S4][START] wav_bytes=%d", len(wav))
 
-	txt, _, err := s.stt.Recognize(ctx, wav)
+	txt, _, err := s.stt.Recognize(ctx, wav, opts)
 	if err == nil {
 		log.Printf("[S4][OK]")
 		return txt, nil
@@ -28,10 +28,11 @@ func (s *S4WAVtoText) Run(ctx context.Context, wav []byte) (string, error) {
 	log.Printf("[S4][ERR][FIRST] err=%v", err)
 
 	// === RETRY 1 раз с новым контекстом ===
-	retryCtx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	// без отмены родителя, но с его значениями: UsageScope нужен учёту расходов
+	retryCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 20*time.Second)
 	defer cancel()
 
-	txt, _, err2 := s.stt.Recognize(retryCtx, wav)
+	txt, _, err2 := s.stt.Recognize(retryCtx, wav, opts)
 	if err2 == nil {
 		log.Printf("[S4][OK][RETRY]")
 		return txt, nil
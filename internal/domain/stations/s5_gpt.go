@@ -22,11 +22,11 @@ func NewS5GPT(gpt ports.GPTService) *S5GPT {
 	return &S5GPT{gpt: gpt}
 }
 
-func (s *S5GPT) Run(ctx context.Context, prev, raw string) (string, error) {
+func (s *S5GPT) Run(ctx context.Context, prev, raw string, opts ports.ChunkOptions) (string, error) {
 	log.Printf("[S5][IN-prev] %q", trim(prev, 180))
 	log.Printf("[S5][IN-raw ] %q", trim(raw, 180))
 
-	out, err := s.gpt.ProcessChunk(ctx, prev, raw)
+	out, err := s.gpt.ProcessChunk(ctx, prev, raw, opts)
 	if err != nil {
 		log.Printf("[S5][ERR] %v", err)
 		return "", err
@@ -0,0 +1,435 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/domain/stations"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+// расширение → тип media; всё прочее отклоняем до записи на диск
+var uploadFormats = map[string]string{
+	".mp3":  "audio",
+	".m4a":  "audio",
+	".aac":  "audio",
+	".wav":  "audio",
+	".ogg":  "audio",
+	".oga":  "audio",
+	".opus": "audio",
+	".flac": "audio",
+	".amr":  "audio",
+	".wma":  "audio",
+	".mp4":  "video",
+	".mov":  "video",
+	".mkv":  "video",
+	".webm": "video",
+	".avi":  "video",
+	".3gp":  "video",
+}
+
+const (
+	uploadMetaFile = "upload.json"
+	uploadPartFile = "data.part"
+
+	// очистка каталога загрузок: брошенные — через сутки без новых частей;
+	// обработанные — когда оригинал уже в storage и media завершена (или старше недели)
+	uploadCleanupEvery = time.Hour
+	uploadAbandonAfter = 24 * time.Hour
+	uploadKeepAfter    = 7 * 24 * time.Hour
+)
+
+// UploadService — файл по частям на диск → проверка ffprobe →
+// media с StorageURL → та же обработка, что у записи (режим VOD)
+type UploadService struct {
+	repo     ports.MediaRepository
+	media    ports.MediaProcessor
+	storage  ports.ObjectStorage
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	locks map[string]*uploadLock // uploadID → части одной загрузки пишутся по очереди
+}
+
+type uploadLock struct {
+	mu   sync.Mutex
+	refs int // держат или ждут; 0 → запись удаляется из locks
+}
+
+func NewUploadService(
+	repo ports.MediaRepository,
+	media ports.MediaProcessor,
+	storage ports.ObjectStorage,
+	dir string,
+	maxBytes int64,
+) *UploadService {
+	return &UploadService{
+		repo:     repo,
+		media:    media,
+		storage:  storage,
+		dir:      dir,
+		maxBytes: maxBytes,
+		locks:    make(map[string]*uploadLock),
+	}
+}
+
+func (s *UploadService) Begin(ctx context.Context, filename string, total int64, roomID, user string) (*models.Upload, error) {
+	filename = cleanFilename(filename)
+	if _, ok := uploadFormats[strings.ToLower(filepath.Ext(filename))]; !ok {
+		return nil, fmt.Errorf("%w: %s", ports.ErrUploadFormat, filepath.Ext(filename))
+	}
+	if total < 0 || total > s.maxBytes {
+		return nil, ports.ErrUploadTooLarge
+	}
+
+	id, err := newUploadID()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Join(s.dir, id), 0755); err != nil {
+		return nil, fmt.Errorf("upload dir: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, id, uploadPartFile), nil, 0644); err != nil {
+		return nil, fmt.Errorf("upload part: %w", err)
+	}
+
+	up := &models.Upload{
+		ID:        id,
+		Filename:  filename,
+		Total:     total,
+		RoomID:    roomID,
+		User:      user,
+		CreatedAt: time.Now(),
+	}
+	if err := s.save(up); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[UPLOAD][BEGIN] id=%s file=%q total=%d", id, filename, total)
+	return up, nil
+}
+
+func (s *UploadService) Write(ctx context.Context, id string, offset int64, part io.Reader) (*models.Upload, error) {
+	unlock := s.lock(id)
+	defer unlock()
+
+	up, err := s.load(id)
+	if err != nil {
+		return nil, err
+	}
+	if up.Complete() {
+		// повтор последней части: после обрыва ответа или сбоя finalize
+		if !up.Started {
+			return up, s.finalize(ctx, up)
+		}
+		return up, nil
+	}
+	if offset != up.Received {
+		return up, ports.ErrUploadOffset
+	}
+
+	limit := s.maxBytes - up.Received
+	if up.Total > 0 {
+		limit = up.Total - up.Received
+	}
+
+	partPath := filepath.Join(s.dir, id, uploadPartFile)
+	f, err := os.OpenFile(partPath, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open part: %w", err)
+	}
+
+	n, err := io.Copy(f, io.LimitReader(part, limit+1))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if n > limit {
+		// откатываем часть целиком: клиент повторит с того же offset
+		_ = os.Truncate(partPath, up.Received)
+		return up, ports.ErrUploadTooLarge
+	}
+	if err != nil {
+		// принятое до обрыва оставляем — клиент продолжит с received
+		if st, serr := os.Stat(partPath); serr == nil {
+			up.Received = st.Size()
+			_ = s.save(up)
+		}
+		return up, fmt.Errorf("write part: %w", err)
+	}
+
+	up.Received += n
+	if err := s.save(up); err != nil {
+		return nil, err
+	}
+
+	if up.Complete() {
+		if err := s.finalize(ctx, up); err != nil {
+			return up, err
+		}
+	}
+	return up, nil
+}
+
+func (s *UploadService) Status(ctx context.Context, id string) (*models.Upload, error) {
+	return s.load(id)
+}
+
+// finalize — проверка до старта обработки: неподходящий файл сразу удаляем.
+// Повторяемая: каждый шаг смотрит, сделан ли он уже (файл переименован,
+// media создана), — сбой посередине лечится повтором последней части
+func (s *UploadService) finalize(ctx context.Context, up *models.Upload) error {
+	dir := filepath.Join(s.dir, up.ID)
+	path := filepath.Join(dir, up.Filename)
+
+	if up.MediaID == 0 {
+		if err := s.finalizeFile(ctx, up, dir, path); err != nil {
+			return err
+		}
+
+		media, err := s.repo.InsertMedia(ctx, &models.Media{
+			SourceURL: path,
+			Type:      uploadFormats[strings.ToLower(filepath.Ext(up.Filename))],
+		})
+		if err != nil {
+			return err
+		}
+
+		up.MediaID = media.ID
+		if err := s.save(up); err != nil {
+			return err
+		}
+		go s.archiveOriginal(media.ID, path)
+	}
+
+	// обработка живёт дольше HTTP-запроса; прогресс — событиями в room
+	procCtx := ports.WithUsageScope(context.Background(), ports.UsageScope{User: up.User})
+	if _, err := s.media.Process(procCtx, "", up.RoomID, up.MediaID); err != nil {
+		return fmt.Errorf("start processing: %w", err)
+	}
+
+	up.Started = true
+	if err := s.save(up); err != nil {
+		return err
+	}
+
+	log.Printf("[UPLOAD][DONE] id=%s media=%d bytes=%d", up.ID, up.MediaID, up.Received)
+	return nil
+}
+
+// finalizeFile — data.part → имя файла (если ещё не) и ffprobe
+func (s *UploadService) finalizeFile(ctx context.Context, up *models.Upload, dir, path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := os.Rename(filepath.Join(dir, uploadPartFile), path); err != nil {
+			return fmt.Errorf("finalize upload: %w", err)
+		}
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	meta, err := stations.ProbeFile(probeCtx, path)
+	cancel()
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		log.Printf("[UPLOAD][REJECT] id=%s err=%v", up.ID, err)
+		return fmt.Errorf("%w: %v", ports.ErrUploadFormat, err)
+	}
+
+	log.Printf("[UPLOAD][PROBE] id=%s dur=%ds", up.ID, meta.DurationSec)
+	return nil
+}
+
+// archiveOriginal — оригинал в storage → media.storage_url; обработка идёт
+// по локальной копии и от архива не зависит
+func (s *UploadService) archiveOriginal(mediaID int, path string) {
+	ctx := context.Background()
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Printf("[UPLOAD][ARCHIVE][FAIL] media=%d err=%v", mediaID, err)
+		return
+	}
+	defer f.Close()
+
+	st, err := f.Stat()
+	if err != nil {
+		log.Printf("[UPLOAD][ARCHIVE][FAIL] media=%d err=%v", mediaID, err)
+		return
+	}
+
+	key := fmt.Sprintf("media/%d/original%s", mediaID, strings.ToLower(filepath.Ext(path)))
+	url, err := s.storage.Put(ctx, key, f, st.Size(), mime.TypeByExtension(filepath.Ext(path)))
+	if err != nil {
+		log.Printf("[UPLOAD][ARCHIVE][FAIL] media=%d err=%v", mediaID, err)
+		return
+	}
+
+	if err := s.repo.SetMediaStorage(ctx, mediaID, url); err != nil {
+		log.Printf("[UPLOAD][ARCHIVE][DB][FAIL] media=%d err=%v", mediaID, err)
+		return
+	}
+	log.Printf("[UPLOAD][ARCHIVE] media=%d url=%s", mediaID, url)
+}
+
+func (s *UploadService) lock(id string) func() {
+	s.mu.Lock()
+	l, ok := s.locks[id]
+	if !ok {
+		l = &uploadLock{}
+		s.locks[id] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		s.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(s.locks, id)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// RunCleanup — до отмены ctx: брошенные и уже ненужные каталоги загрузок
+func (s *UploadService) RunCleanup(ctx context.Context) {
+	ticker := time.NewTicker(uploadCleanupEvery)
+	defer ticker.Stop()
+
+	for {
+		s.cleanup(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *UploadService) cleanup(ctx context.Context) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[UPLOAD][CLEANUP][FAIL] err=%v", err)
+		}
+		return
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() || !validUploadID(e.Name()) {
+			continue
+		}
+		// под тем же замком, что и Write: часть не допишется в удаляемый каталог
+		unlock := s.lock(e.Name())
+		if s.removable(ctx, e.Name()) {
+			_ = os.RemoveAll(filepath.Join(s.dir, e.Name()))
+			log.Printf("[UPLOAD][CLEANUP] id=%s", e.Name())
+		}
+		unlock()
+	}
+}
+
+// removable — брошена (нет частей uploadAbandonAfter) или обработана:
+// оригинал в storage и media завершена / старше uploadKeepAfter
+func (s *UploadService) removable(ctx context.Context, id string) bool {
+	st, err := os.Stat(filepath.Join(s.dir, id, uploadMetaFile))
+	if err != nil {
+		return false
+	}
+	up, err := s.load(id)
+	if err != nil {
+		return false
+	}
+
+	if up.MediaID == 0 {
+		return time.Since(st.ModTime()) > uploadAbandonAfter
+	}
+
+	media, err := s.repo.GetMediaByID(ctx, up.MediaID)
+	if err != nil {
+		return false
+	}
+	if media == nil {
+		return true
+	}
+	if media.StorageURL == nil {
+		return false
+	}
+	return media.FinishedAt != nil || time.Since(up.CreatedAt) > uploadKeepAfter
+}
+
+func (s *UploadService) load(id string) (*models.Upload, error) {
+	if !validUploadID(id) {
+		return nil, ports.ErrUploadNotFound
+	}
+
+	b, err := os.ReadFile(filepath.Join(s.dir, id, uploadMetaFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ports.ErrUploadNotFound
+		}
+		return nil, err
+	}
+
+	var up models.Upload
+	if err := json.Unmarshal(b, &up); err != nil {
+		return nil, fmt.Errorf("decode upload: %w", err)
+	}
+	return &up, nil
+}
+
+func (s *UploadService) save(up *models.Upload) error {
+	b, err := json.Marshal(up)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.dir, up.ID, uploadMetaFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return fmt.Errorf("save upload: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+func newUploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validUploadID — id идёт в путь на диске: только hex нашей длины
+func validUploadID(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+// cleanFilename — имя без каталогов; оно же станет title media
+func cleanFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" || name == "" || strings.HasPrefix(name, ".") {
+		return "upload" + filepath.Ext(name)
+	}
+	return name
+}
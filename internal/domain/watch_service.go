@@ -0,0 +1,290 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/domain/stations"
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+const (
+	watchTick        = 15 * time.Second
+	watchMinInterval = 30
+	watchDefInterval = 120
+	watchCheckTO     = 90 * time.Second
+)
+
+// WatchService — опрос каналов: начался эфир → media и сессия в room
+// подписки, кончился → сессия останавливается
+type WatchService struct {
+	repo     ports.WatchRepository
+	live     *stations.S1LiveCheck
+	sessions *SessionManager
+	notify   ports.Notifier
+
+	mu       sync.Mutex
+	checking map[int]bool // проверка подписки уже идёт
+}
+
+func NewWatchService(
+	repo ports.WatchRepository,
+	live *stations.S1LiveCheck,
+	sessions *SessionManager,
+	notify ports.Notifier,
+) *WatchService {
+	return &WatchService{
+		repo:     repo,
+		live:     live,
+		sessions: sessions,
+		notify:   notify,
+		checking: make(map[int]bool),
+	}
+}
+
+// Run — до отмены ctx; каждая подписка проверяется раз в свой interval_sec
+func (s *WatchService) Run(ctx context.Context) {
+	ticker := time.NewTicker(watchTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		watches, err := s.repo.ListWatches(ctx)
+		if err != nil {
+			log.Printf("[WATCH][LIST][FAIL] err=%v", err)
+			continue
+		}
+
+		for i := range watches {
+			w := watches[i]
+			if !w.Enabled || !watchDue(&w) {
+				continue
+			}
+			go func() {
+				if err := s.check(ctx, &w); err != nil {
+					log.Printf("[WATCH][FAIL] id=%d err=%v", w.ID, err)
+				}
+			}()
+		}
+	}
+}
+
+func watchDue(w *models.WatchSubscription) bool {
+	if w.LastCheckedAt == nil {
+		return true
+	}
+	return time.Since(*w.LastCheckedAt) >= time.Duration(w.IntervalSec)*time.Second
+}
+
+func (s *WatchService) Create(ctx context.Context, w *models.WatchSubscription) error {
+	if err := validateWatch(w); err != nil {
+		return err
+	}
+	if err := s.repo.InsertWatch(ctx, w); err != nil {
+		return err
+	}
+
+	log.Printf("[WATCH][CREATE] id=%d url=%s room=%s", w.ID, w.URL, w.RoomID)
+	return nil
+}
+
+func (s *WatchService) Update(ctx context.Context, w *models.WatchSubscription) error {
+	cur, err := s.repo.GetWatch(ctx, w.ID)
+	if err != nil {
+		return err
+	}
+	if cur == nil {
+		return ports.ErrWatchNotFound
+	}
+	if err := validateWatch(w); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateWatch(ctx, w); err != nil {
+		return err
+	}
+
+	// выключили или сменили канал / room — текущая сессия к подписке больше не относится
+	if !w.Enabled || w.URL != cur.URL || w.RoomID != cur.RoomID {
+		s.sessions.Stop(watchKey(w.ID))
+	}
+	return nil
+}
+
+func (s *WatchService) Delete(ctx context.Context, id int) error {
+	cur, err := s.repo.GetWatch(ctx, id)
+	if err != nil {
+		return err
+	}
+	if cur == nil {
+		return ports.ErrWatchNotFound
+	}
+	if err := s.repo.DeleteWatch(ctx, id); err != nil {
+		return err
+	}
+
+	s.sessions.Stop(watchKey(id))
+	return nil
+}
+
+func (s *WatchService) List(ctx context.Context) ([]models.WatchSubscription, error) {
+	return s.repo.ListWatches(ctx)
+}
+
+func (s *WatchService) Check(ctx context.Context, id int) (*models.WatchSubscription, error) {
+	w, err := s.repo.GetWatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, ports.ErrWatchNotFound
+	}
+
+	if err := s.check(ctx, w); err != nil {
+		log.Printf("[WATCH][FAIL] id=%d err=%v", id, err)
+	}
+	return s.repo.GetWatch(ctx, id)
+}
+
+// check — один опрос канала; ошибка пишется в last_error подписки
+func (s *WatchService) check(ctx context.Context, w *models.WatchSubscription) error {
+	s.mu.Lock()
+	if s.checking[w.ID] {
+		s.mu.Unlock()
+		return nil
+	}
+	s.checking[w.ID] = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.checking, w.ID)
+		s.mu.Unlock()
+	}()
+
+	key := watchKey(w.ID)
+
+	checkCtx, cancel := context.WithTimeout(ctx, watchCheckTO)
+	b, err := s.live.Run(checkCtx, w.URL)
+	cancel()
+	if err != nil {
+		_ = s.repo.SetWatchState(ctx, w.ID, w.LiveID, 0, err.Error())
+		return err
+	}
+
+	if b == nil {
+		if mediaID, ok := s.sessions.Running(key); ok {
+			s.sessions.Stop(key)
+			s.send(ctx, w, models.Notification{
+				Kind:    models.NoticeLiveEnded,
+				MediaID: mediaID,
+				Text:    "Эфир завершён",
+			})
+		}
+		return s.repo.SetWatchState(ctx, w.ID, w.LiveID, 0, "")
+	}
+
+	// тот же эфир: после рестарта сервера продолжаем его media
+	resume := 0
+	if b.ID == w.LiveID && w.MediaID != nil {
+		resume = *w.MediaID
+	}
+
+	if cur, ok := s.sessions.Running(key); ok {
+		if b.ID == w.LiveID {
+			return s.repo.SetWatchState(ctx, w.ID, w.LiveID, cur, "")
+		}
+		// на канале уже другой эфир
+		s.sessions.Stop(key)
+	}
+
+	user := w.User
+	if user == "" {
+		user = fmt.Sprintf("watch:%d", w.ID)
+	}
+
+	mediaID, err := s.sessions.Start(key, b.URL, w.RoomID, resume, user, ports.SessionOptions{})
+	if errors.Is(err, ports.ErrMediaFinished) {
+		// эфир уже кончился, канал ещё отдаёт его как live; last_checked_at
+		// сдвигаем, иначе опрос идёт каждый тик, а не раз в interval_sec
+		return s.repo.SetWatchState(ctx, w.ID, b.ID, 0, "")
+	}
+	if err != nil {
+		_ = s.repo.SetWatchState(ctx, w.ID, w.LiveID, 0, err.Error())
+		return err
+	}
+
+	log.Printf("[WATCH][LIVE] id=%d live=%s media=%d room=%s resume=%v",
+		w.ID, b.ID, mediaID, w.RoomID, resume > 0)
+
+	if resume == 0 {
+		s.send(ctx, w, models.Notification{
+			Kind:    models.NoticeLiveStarted,
+			MediaID: mediaID,
+			Title:   b.Title,
+			URL:     b.URL,
+			Text:    "Начался эфир: " + b.Title,
+		})
+	}
+
+	return s.repo.SetWatchState(ctx, w.ID, b.ID, mediaID, "")
+}
+
+func (s *WatchService) send(ctx context.Context, w *models.WatchSubscription, n models.Notification) {
+	if s.notify == nil {
+		return
+	}
+	n.RoomID = w.RoomID
+	n.At = time.Now()
+
+	if err := s.notify.Notify(ctx, n); err != nil {
+		log.Printf("[WATCH][NOTIFY][FAIL] id=%d kind=%s err=%v", w.ID, n.Kind, err)
+	}
+}
+
+func watchKey(id int) string { return fmt.Sprintf("watch:%d", id) }
+
+func validateWatch(w *models.WatchSubscription) error {
+	w.URL = strings.TrimSpace(w.URL)
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be http(s)", ports.ErrWatchInvalid)
+	}
+	if strings.TrimSpace(w.RoomID) == "" {
+		return fmt.Errorf("%w: roomID is required", ports.ErrWatchInvalid)
+	}
+
+	if w.IntervalSec == 0 {
+		w.IntervalSec = watchDefInterval
+	}
+	if w.IntervalSec < watchMinInterval {
+		return fmt.Errorf("%w: intervalSec must be >= %d", ports.ErrWatchInvalid, watchMinInterval)
+	}
+	return nil
+}
+
+// ========================================================================
+// Notifiers — одно уведомление во все каналы (room, webhook)
+// ========================================================================
+type Notifiers []ports.Notifier
+
+func (ns Notifiers) Notify(ctx context.Context, n models.Notification) error {
+	var firstErr error
+	for _, x := range ns {
+		if err := x.Notify(ctx, n); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
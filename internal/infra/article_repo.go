@@ -0,0 +1,136 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresArticleRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresArticleRepo(pool *pgxpool.Pool) ports.ArticleRepository {
+	return &PostgresArticleRepo{pool: pool}
+}
+
+const articleColumns = `
+	id, media_id, chunk_from, chunk_to, params, headlines,
+	lead, body, quotes, edited, parent_id, source_truncated,
+	created_at, updated_at
+`
+
+func (r *PostgresArticleRepo) InsertArticle(ctx context.Context, a *models.ArticleDraft) (*models.ArticleDraft, error) {
+	normalizeArticle(a)
+
+	query := `
+		INSERT INTO article_draft (
+			media_id, chunk_from, chunk_to, params, headlines, lead, body, quotes,
+			edited, parent_id, source_truncated
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at, updated_at
+	`
+	row := r.pool.QueryRow(ctx, query,
+		a.MediaID, a.ChunkFrom, a.ChunkTo,
+		a.Params, a.Headlines, a.Lead, a.Body, a.Quotes,
+		a.Edited, a.ParentID, a.SourceTruncated,
+	)
+	if err := row.Scan(&a.ID, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("insert article: %w", err)
+	}
+	return a, nil
+}
+
+func (r *PostgresArticleRepo) UpdateArticle(ctx context.Context, a *models.ArticleDraft) error {
+	normalizeArticle(a)
+
+	query := `
+		UPDATE article_draft
+		SET params = $1, headlines = $2, lead = $3, body = $4, quotes = $5,
+		    edited = $6, source_truncated = $7, updated_at = now()
+		WHERE id = $8
+		RETURNING updated_at
+	`
+	row := r.pool.QueryRow(ctx, query,
+		a.Params, a.Headlines, a.Lead, a.Body, a.Quotes, a.Edited, a.SourceTruncated, a.ID,
+	)
+	if err := row.Scan(&a.UpdatedAt); err != nil {
+		if err.Error() == "no rows in result set" {
+			return ports.ErrArticleNotFound
+		}
+		return fmt.Errorf("update article: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresArticleRepo) GetArticle(ctx context.Context, id int) (*models.ArticleDraft, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+articleColumns+` FROM article_draft WHERE id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("get article: %w", err)
+	}
+
+	list, err := scanArticles(rows)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+func (r *PostgresArticleRepo) ListArticles(ctx context.Context, mediaID int) ([]models.ArticleDraft, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+articleColumns+`
+		FROM article_draft
+		WHERE media_id = $1
+		ORDER BY id DESC
+	`, mediaID)
+	if err != nil {
+		return nil, fmt.Errorf("list articles: %w", err)
+	}
+
+	return scanArticles(rows)
+}
+
+func scanArticles(rows pgx.Rows) ([]models.ArticleDraft, error) {
+	defer rows.Close()
+
+	out := []models.ArticleDraft{}
+	for rows.Next() {
+		var a models.ArticleDraft
+		if err := rows.Scan(
+			&a.ID,
+			&a.MediaID,
+			&a.ChunkFrom,
+			&a.ChunkTo,
+			&a.Params,
+			&a.Headlines,
+			&a.Lead,
+			&a.Body,
+			&a.Quotes,
+			&a.Edited,
+			&a.ParentID,
+			&a.SourceTruncated,
+			&a.CreatedAt,
+			&a.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+
+	return out, rows.Err()
+}
+
+// JSONB-колонки NOT NULL: nil-слайсы пишем как []
+func normalizeArticle(a *models.ArticleDraft) {
+	if a.Headlines == nil {
+		a.Headlines = []string{}
+	}
+	if a.Quotes == nil {
+		a.Quotes = []models.ArticleQuote{}
+	}
+}
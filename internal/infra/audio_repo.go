@@ -0,0 +1,56 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresAudioRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresAudioRepo(pool *pgxpool.Pool) ports.AudioSegmentRepository {
+	return &PostgresAudioRepo{pool: pool}
+}
+
+func (r *PostgresAudioRepo) InsertAudioSegment(ctx context.Context, seg *models.AudioSegment) error {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO media_audio_segment (media_id, seq, offset_ms, duration_ms, bytes, storage_url)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, seg.MediaID, seg.Seq, seg.OffsetMs, seg.DurationMs, seg.Bytes, seg.StorageURL,
+	).Scan(&seg.ID, &seg.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert audio segment: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresAudioRepo) ListAudioSegments(ctx context.Context, mediaID int) ([]models.AudioSegment, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, media_id, seq, offset_ms, duration_ms, bytes, storage_url, created_at
+		FROM media_audio_segment
+		WHERE media_id = $1
+		ORDER BY seq ASC
+	`, mediaID)
+	if err != nil {
+		return nil, fmt.Errorf("list audio segments: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.AudioSegment{}
+	for rows.Next() {
+		var s models.AudioSegment
+		if err := rows.Scan(
+			&s.ID, &s.MediaID, &s.Seq, &s.OffsetMs, &s.DurationMs, &s.Bytes, &s.StorageURL, &s.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
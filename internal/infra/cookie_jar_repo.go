@@ -0,0 +1,131 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresCookieJarRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresCookieJarRepo(pool *pgxpool.Pool) ports.CookieJarRepository {
+	return &PostgresCookieJarRepo{pool: pool}
+}
+
+const cookieJarColumns = `
+	id, domain, name, content, active, status, last_error, checked_at, created_at
+`
+
+func scanCookieJar(row pgx.Row, j *models.CookieJar) error {
+	return row.Scan(
+		&j.ID,
+		&j.Domain,
+		&j.Name,
+		&j.Content,
+		&j.Active,
+		&j.Status,
+		&j.LastError,
+		&j.CheckedAt,
+		&j.CreatedAt,
+	)
+}
+
+func (r *PostgresCookieJarRepo) InsertJar(ctx context.Context, jar *models.CookieJar) error {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO cookie_jar (domain, name, content)
+		VALUES ($1, $2, $3)
+		RETURNING id, active, status, created_at
+	`, jar.Domain, jar.Name, jar.Content,
+	).Scan(&jar.ID, &jar.Active, &jar.Status, &jar.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert cookie jar: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresCookieJarRepo) GetJar(ctx context.Context, id int) (*models.CookieJar, error) {
+	var j models.CookieJar
+	err := scanCookieJar(r.pool.QueryRow(ctx, `
+		SELECT `+cookieJarColumns+`
+		FROM cookie_jar
+		WHERE id = $1
+	`, id), &j)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get cookie jar: %w", err)
+	}
+	return &j, nil
+}
+
+func (r *PostgresCookieJarRepo) ListJars(ctx context.Context) ([]models.CookieJar, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+cookieJarColumns+`
+		FROM cookie_jar
+		ORDER BY domain ASC, id DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list cookie jars: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.CookieJar{}
+	for rows.Next() {
+		var j models.CookieJar
+		if err := scanCookieJar(rows, &j); err != nil {
+			return nil, err
+		}
+		out = append(out, j)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresCookieJarRepo) SetJarCheck(ctx context.Context, id int, status, lastError string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE cookie_jar
+		SET status = $1, last_error = $2, checked_at = now()
+		WHERE id = $3
+	`, status, lastError, id)
+	if err != nil {
+		return fmt.Errorf("set cookie jar check: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresCookieJarRepo) ActivateJar(ctx context.Context, id int) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE cookie_jar
+		SET active = false
+		WHERE active AND domain = (SELECT domain FROM cookie_jar WHERE id = $1)
+	`, id)
+	if err != nil {
+		return fmt.Errorf("deactivate cookie jars: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE cookie_jar SET active = true WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("activate cookie jar: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresCookieJarRepo) DeleteJar(ctx context.Context, id int) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM cookie_jar WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete cookie jar: %w", err)
+	}
+	return nil
+}
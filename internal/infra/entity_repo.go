@@ -0,0 +1,126 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresEntityRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresEntityRepo(pool *pgxpool.Pool) ports.EntityRepository {
+	return &PostgresEntityRepo{pool: pool}
+}
+
+func (r *PostgresEntityRepo) ReplaceChunkEntities(
+	ctx context.Context,
+	chunk *models.MediaChunk,
+	entities []models.Entity,
+) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM media_entity WHERE chunk_id = $1`, chunk.ID); err != nil {
+		return fmt.Errorf("delete chunk entities: %w", err)
+	}
+
+	for _, e := range entities {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO media_entity (media_id, chunk_id, chunk_number, kind, value, normalized)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, chunk.MediaID, chunk.ID, chunk.ChunkNumber, e.Kind, e.Value, e.Normalized)
+		if err != nil {
+			return fmt.Errorf("insert entity: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresEntityRepo) ListMediaEntities(ctx context.Context, mediaID int) ([]models.Entity, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, media_id, chunk_id, chunk_number, kind, value, normalized, created_at
+		FROM media_entity
+		WHERE media_id = $1
+		ORDER BY chunk_number ASC, id ASC
+	`, mediaID)
+	if err != nil {
+		return nil, fmt.Errorf("list entities: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.Entity{}
+	for rows.Next() {
+		var e models.Entity
+		if err := rows.Scan(
+			&e.ID,
+			&e.MediaID,
+			&e.ChunkID,
+			&e.ChunkNumber,
+			&e.Kind,
+			&e.Value,
+			&e.Normalized,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresEntityRepo) FindMentions(
+	ctx context.Context,
+	query, kind string,
+	limit int,
+) ([]models.EntityMention, error) {
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT DISTINCT ON (e.media_id, e.chunk_number)
+		       e.media_id, m.source_url, m.created_at,
+		       e.chunk_number, e.kind, e.value, COALESCE(c.text, '')
+		FROM media_entity e
+		JOIN media m ON m.id = e.media_id
+		JOIN media_chunk c ON c.id = e.chunk_id
+		WHERE e.normalized LIKE $1 || '%'
+		  AND ($2 = '' OR e.kind = $2)
+		ORDER BY e.media_id DESC, e.chunk_number ASC
+		LIMIT $3
+	`, escapeLike(query), kind, limit)
+	if err != nil {
+		return nil, fmt.Errorf("find mentions: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.EntityMention{}
+	for rows.Next() {
+		var m models.EntityMention
+		if err := rows.Scan(
+			&m.MediaID,
+			&m.SourceURL,
+			&m.MediaAt,
+			&m.ChunkNumber,
+			&m.Kind,
+			&m.Value,
+			&m.Text,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// escapeLike — % и _ из запроса пользователя ищем буквально
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,92 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresGlossaryRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresGlossaryRepo(pool *pgxpool.Pool) ports.GlossaryRepository {
+	return &PostgresGlossaryRepo{pool: pool}
+}
+
+func (r *PostgresGlossaryRepo) InsertTerm(ctx context.Context, t *models.GlossaryTerm) (*models.GlossaryTerm, error) {
+	if t.Aliases == nil {
+		t.Aliases = []string{}
+	}
+
+	query := `
+		INSERT INTO glossary_term (media_id, term, spelling, aliases)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	row := r.pool.QueryRow(ctx, query, t.MediaID, t.Term, t.Spelling, t.Aliases)
+	if err := row.Scan(&t.ID, &t.CreatedAt); err != nil {
+		return nil, fmt.Errorf("insert glossary term: %w", err)
+	}
+	return t, nil
+}
+
+func (r *PostgresGlossaryRepo) UpdateTerm(ctx context.Context, t *models.GlossaryTerm) error {
+	if t.Aliases == nil {
+		t.Aliases = []string{}
+	}
+
+	// PUT — полная замена, media_id тоже; t — строка как она сохранена
+	query := `
+		UPDATE glossary_term
+		SET media_id = $1, term = $2, spelling = $3, aliases = $4
+		WHERE id = $5
+		RETURNING media_id, term, spelling, aliases, created_at
+	`
+	row := r.pool.QueryRow(ctx, query, t.MediaID, t.Term, t.Spelling, t.Aliases, t.ID)
+	if err := row.Scan(&t.MediaID, &t.Term, &t.Spelling, &t.Aliases, &t.CreatedAt); err != nil {
+		if err.Error() == "no rows in result set" {
+			return ports.ErrGlossaryTermNotFound
+		}
+		return fmt.Errorf("update glossary term: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresGlossaryRepo) DeleteTerm(ctx context.Context, id int) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM glossary_term WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete glossary term: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ports.ErrGlossaryTermNotFound
+	}
+	return nil
+}
+
+func (r *PostgresGlossaryRepo) ListTerms(ctx context.Context, mediaID int) ([]models.GlossaryTerm, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, media_id, term, spelling, aliases, created_at
+		FROM glossary_term
+		WHERE media_id IS NULL OR media_id = $1
+		ORDER BY media_id NULLS FIRST, id ASC
+	`, mediaID)
+	if err != nil {
+		return nil, fmt.Errorf("list glossary: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.GlossaryTerm{}
+	for rows.Next() {
+		var t models.GlossaryTerm
+		if err := rows.Scan(&t.ID, &t.MediaID, &t.Term, &t.Spelling, &t.Aliases, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+
+	return out, rows.Err()
+}
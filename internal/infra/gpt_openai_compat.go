@@ -0,0 +1,181 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+// OpenAICompatClient — любой сервер с OpenAI /chat/completions:
+// llama.cpp server, Ollama (/v1), vLLM и т.п.
+type OpenAICompatClient struct {
+	baseURL         string // например http://127.0.0.1:8081/v1
+	authHeader      string // имя заголовка, например "Authorization"
+	authValue       string // значение, например "Bearer sk-local"
+	model           string
+	provider        string // имя в ledger
+	client          *http.Client
+	usage           ports.UsageRecorder
+	promptPrice     float64 // за 1M токенов, обычно 0 для локальной модели
+	completionPrice float64
+}
+
+type OpenAICompatConfig struct {
+	BaseURL    string
+	AuthHeader string // "Authorization: Bearer xxx" или пусто
+	Model      string
+	Provider   string
+}
+
+func NewOpenAICompatClient(cfg OpenAICompatConfig, usage ports.UsageRecorder) ports.LLMClient {
+	c := &OpenAICompatClient{
+		baseURL:         strings.TrimRight(cfg.BaseURL, "/"),
+		model:           cfg.Model,
+		provider:        cfg.Provider,
+		client:          &http.Client{},
+		usage:           usage,
+		promptPrice:     envFloat("LLM_PROMPT_PRICE", 0),
+		completionPrice: envFloat("LLM_COMPLETION_PRICE", 0),
+	}
+
+	if name, value, ok := strings.Cut(cfg.AuthHeader, ":"); ok {
+		c.authHeader = strings.TrimSpace(name)
+		c.authValue = strings.TrimSpace(value)
+	}
+
+	if c.provider == "" {
+		c.provider = "openai-compat"
+	}
+
+	return c
+}
+
+func (c *OpenAICompatClient) ProcessChunk(ctx context.Context, prev, raw string, opts ports.ChunkOptions) (string, error) {
+	if c.baseURL == "" {
+		return "", fmt.Errorf("no LLM_BASE_URL")
+	}
+
+	prev = sanitize(prev)
+	raw = sanitize(raw)
+
+	body := orRequest{
+		Model:     c.model,
+		MaxTokens: 300,
+		Messages: []orMessage{
+			{Role: "system", Content: chunkSystemPrompt + glossaryPrompt(opts.Glossary) + sessionPrompt(opts.Prompt)},
+			{Role: "user", Content: chunkUserPrompt(prev, raw)},
+		},
+	}
+
+	return c.chat(ctx, "chunk", body)
+}
+
+func (c *OpenAICompatClient) Complete(ctx context.Context, req ports.CompletionRequest) (string, error) {
+	if c.baseURL == "" {
+		return "", fmt.Errorf("no LLM_BASE_URL")
+	}
+
+	body := orRequest{
+		Model:          c.model,
+		MaxTokens:      completionMaxTokens(req),
+		ResponseFormat: responseFormat(req),
+		Messages: []orMessage{
+			{Role: "system", Content: sanitize(req.System)},
+			{Role: "user", Content: sanitize(req.User)},
+		},
+	}
+
+	return c.chat(ctx, req.Operation, body)
+}
+
+func (c *OpenAICompatClient) chat(ctx context.Context, operation string, body orRequest) (string, error) {
+	j, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= 3; attempt++ {
+		out, err := c.do(ctx, j)
+		if err != nil {
+			lastErr = err
+			log.Printf("[LLM][ERR] provider=%s attempt=%d err=%v", c.provider, attempt, err)
+			continue
+		}
+
+		c.recordUsage(ctx, operation, out)
+		return out.Choices[0].Message.Content, nil
+	}
+
+	return "", fmt.Errorf("llm failed after retries: %w", lastErr)
+}
+
+func (c *OpenAICompatClient) do(ctx context.Context, body []byte) (orResponse, error) {
+	var out orResponse
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"POST",
+		c.baseURL+"/chat/completions",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return out, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.authHeader != "" {
+		req.Header.Set(c.authHeader, c.authValue)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return out, err
+	}
+	defer resp.Body.Close()
+
+	rawResp, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return out, fmt.Errorf("http %d: %s", resp.StatusCode, trim(string(rawResp), 200))
+	}
+
+	if err := json.Unmarshal(rawResp, &out); err != nil {
+		return out, fmt.Errorf("decode: %w", err)
+	}
+
+	if len(out.Choices) == 0 {
+		return out, fmt.Errorf("empty choices")
+	}
+
+	return out, nil
+}
+
+func (c *OpenAICompatClient) recordUsage(ctx context.Context, operation string, out orResponse) {
+	if out.Usage == nil {
+		return
+	}
+
+	model := out.Model
+	if model == "" {
+		model = c.model
+	}
+
+	recordLLMUsage(ctx, c.usage, &models.UsageRecord{
+		Provider:         c.provider,
+		Operation:        operation,
+		Model:            model,
+		PromptTokens:     out.Usage.PromptTokens,
+		CompletionTokens: out.Usage.CompletionTokens,
+		Cost: float64(out.Usage.PromptTokens)*c.promptPrice/1e6 +
+			float64(out.Usage.CompletionTokens)*c.completionPrice/1e6,
+		Currency: "USD",
+	})
+}
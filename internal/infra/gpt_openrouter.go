@@ -8,46 +8,65 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"strings"
 
+	"github.com/Vovarama1992/journalist/internal/models"
 	"github.com/Vovarama1992/journalist/internal/ports"
 )
 
+const OpenRouterModel = "openai/gpt-5.1"
+
 type GPTClient struct {
 	apiKey string
 	client *http.Client
+	usage  ports.UsageRecorder
+
+	// цены за 1M токенов — только если OpenRouter не вернул usage.cost
+	promptPrice     float64
+	completionPrice float64
 }
 
-func NewGPTClient() ports.GPTService {
+func NewGPTClient(usage ports.UsageRecorder) ports.LLMClient {
 	return &GPTClient{
-		apiKey: os.Getenv("OPENROUTER_API_KEY"),
-		client: &http.Client{},
+		apiKey:          os.Getenv("OPENROUTER_API_KEY"),
+		client:          &http.Client{},
+		usage:           usage,
+		promptPrice:     envFloat("OPENROUTER_PROMPT_PRICE", 0),
+		completionPrice: envFloat("OPENROUTER_COMPLETION_PRICE", 0),
 	}
 }
 
-// sanitize: убираем битый UTF-8
-func sanitize(s string) string {
-	return strings.ToValidUTF8(s, "")
+type orUsageRequest struct {
+	Include bool `json:"include"`
 }
 
-type orMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+type orResponseFormat struct {
+	Type string `json:"type"` // "json_object"
 }
 
 type orRequest struct {
-	Model     string      `json:"model"`
-	Messages  []orMessage `json:"messages"`
-	MaxTokens int         `json:"max_tokens"`
+	Model          string            `json:"model"`
+	Messages       []orMessage       `json:"messages"`
+	MaxTokens      int               `json:"max_tokens"`
+	Usage          *orUsageRequest   `json:"usage,omitempty"`
+	ResponseFormat *orResponseFormat `json:"response_format,omitempty"`
+}
+
+type orUsage struct {
+	PromptTokens     int      `json:"prompt_tokens"`
+	CompletionTokens int      `json:"completion_tokens"`
+	TotalTokens      int      `json:"total_tokens"`
+	Cost             *float64 `json:"cost"` // кредиты OpenRouter (USD)
 }
 
 type orResponse struct {
+	Model   string `json:"model"`
 	Choices []struct {
 		Message orMessage `json:"message"`
 	} `json:"choices"`
+	Usage *orUsage `json:"usage"`
 }
 
-func (g *GPTClient) ProcessChunk(ctx context.Context, prev, raw string) (string, error) {
+func (g *GPTClient) ProcessChunk(ctx context.Context, prev, raw string, opts ports.ChunkOptions) (string, error) {
 	if g.apiKey == "" {
 		return "", fmt.Errorf("no OPENROUTER_API_KEY")
 	}
@@ -55,74 +74,40 @@ func (g *GPTClient) ProcessChunk(ctx context.Context, prev, raw string) (string,
 	prev = sanitize(prev)
 	raw = sanitize(raw)
 
-	systemPrompt := `У тебя есть два текста:
-
-previous — это КОНЕЦ уже отображаемого текста на фронтенде
-(последнее слово, фраза, предложение ИЛИ специальный fallback-блок).
-raw — новый сырой ASR-текст.
-
-ВАЖНО:
-— Ты НЕ переписываешь и НЕ пересобираешь прошлый текст.
-— Ты НЕ цензор и НЕ оцениваешь «качество» или «смысл».
-— Даже странный, кривой или обрывочный текст — это ТЕКСТ.
-— ЗАПРЕЩЕНО возвращать пустую строку, если raw содержит речь.
-— previous может быть обычным текстом ИЛИ fallback-блоком с многоточием.
-— Если previous — fallback-блок, это НЕ причина молчать.
-
-ПРИОРИТЕТЫ (СТРОГО):
-1) Очеловечивание raw.
-2) Совместимость с previous.
-Очеловечивание ВСЕГДА важнее.
-
-ЧТО ТЫ ДЕЛАЕШЬ:
-
-1) Очеловечивание
-— Преврати raw в читаемый человеческий текст.
-— НЕ меняй смысл и НЕ перефразируй свободно.
-— Если в raw есть РЕЧЬ, результат ОБЯЗАН быть непустым.
-
-2) Продолжение текста
-— Если previous — обычный текст:
-  • верни фрагмент, который логично ПРОДОЛЖАЕТ previous.
-  • разрешено выбрать регистр букв и перенос строки.
-— Если previous — fallback-блок с многоточием:
-  • НЕ пытайся с ним состыковываться,
-  • просто верни очеловеченный результат raw.
-
-3) Перекрытие
-— Удаляй ТОЛЬКО прямое текстовое перекрытие
-  (буквальное или почти буквальное повторение конца previous).
-— Смысловое сходство НЕ является перекрытием.
-— Если raw содержит речь — текст НУЖНО вернуть.
-
-4) Fallback (ИСПОЛЬЗУЙ ТОЛЬКО ЕСЛИ НЕЛЬЗЯ ИНАЧЕ)
-— Если raw содержит речь, но после удаления ПРЯМОГО перекрытия
-  невозможно вернуть ни одного слова,
-  верни следующий формат:
-
-\n\n...
-(КРАТКОЕ ОБЪЯСНЕНИЕ ПРИЧИНЫ: перекрытие, обрыв фразы, шум, и т.п.)
-
-— Причину формулируй по фактической ситуации.
-— Если previous уже был fallback-блоком,
-  это НЕ запрещает вернуть нормальный текст при наличии речи.
-
-ПРАВИЛА:
-— previous никогда не переписывай.
-— previous никогда не возвращай.
-— Не объясняй свои действия вне указанного формата.
-— Верни один цельный фрагмент текста.
-`
-
 	body := orRequest{
-		Model:     "openai/gpt-5.1",
+		Model:     OpenRouterModel,
 		MaxTokens: 300,
+		Usage:     &orUsageRequest{Include: true},
 		Messages: []orMessage{
-			{Role: "system", Content: systemPrompt},
-			{Role: "user", Content: fmt.Sprintf("Previous:\n%s\n\nRaw:\n%s", prev, raw)},
+			{Role: "system", Content: chunkSystemPrompt + glossaryPrompt(opts.Glossary) + sessionPrompt(opts.Prompt)},
+			{Role: "user", Content: chunkUserPrompt(prev, raw)},
 		},
 	}
 
+	return g.chat(ctx, "chunk", body)
+}
+
+// Complete — произвольная задача (редактура, статьи, цитаты, ...)
+func (g *GPTClient) Complete(ctx context.Context, req ports.CompletionRequest) (string, error) {
+	if g.apiKey == "" {
+		return "", fmt.Errorf("no OPENROUTER_API_KEY")
+	}
+
+	body := orRequest{
+		Model:          OpenRouterModel,
+		MaxTokens:      completionMaxTokens(req),
+		Usage:          &orUsageRequest{Include: true},
+		ResponseFormat: responseFormat(req),
+		Messages: []orMessage{
+			{Role: "system", Content: sanitize(req.System)},
+			{Role: "user", Content: sanitize(req.User)},
+		},
+	}
+
+	return g.chat(ctx, req.Operation, body)
+}
+
+func (g *GPTClient) chat(ctx context.Context, operation string, body orRequest) (string, error) {
 	j, err := json.Marshal(body)
 	if err != nil {
 		return "", err
@@ -168,8 +153,37 @@ raw — новый сырой ASR-текст.
 			continue
 		}
 
+		g.recordUsage(ctx, operation, out)
+
 		return out.Choices[0].Message.Content, nil
 	}
 
 	return "", fmt.Errorf("gpt failed after retries")
 }
+
+func (g *GPTClient) recordUsage(ctx context.Context, operation string, out orResponse) {
+	if g.usage == nil || out.Usage == nil {
+		return
+	}
+
+	model := out.Model
+	if model == "" {
+		model = OpenRouterModel
+	}
+
+	cost := float64(out.Usage.PromptTokens)*g.promptPrice/1e6 +
+		float64(out.Usage.CompletionTokens)*g.completionPrice/1e6
+	if out.Usage.Cost != nil {
+		cost = *out.Usage.Cost
+	}
+
+	recordLLMUsage(ctx, g.usage, &models.UsageRecord{
+		Provider:         "openrouter",
+		Operation:        operation,
+		Model:            model,
+		PromptTokens:     out.Usage.PromptTokens,
+		CompletionTokens: out.Usage.CompletionTokens,
+		Cost:             cost,
+		Currency:         "USD",
+	})
+}
@@ -0,0 +1,155 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+// Общее для всех OpenAI-совместимых LLM-клиентов (OpenRouter, локальный сервер)
+
+// sanitize: убираем битый UTF-8
+func sanitize(s string) string {
+	return strings.ToValidUTF8(s, "")
+}
+
+type orMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ChunkPromptVersion — менять при любой правке chunkSystemPrompt/glossaryPrompt:
+// входит в ключ кэша S5, иначе из кэша придут ответы старого промпта
+const ChunkPromptVersion = "chunk-v1"
+
+const chunkSystemPrompt = `У тебя есть два текста:
+
+previous — это КОНЕЦ уже отображаемого текста на фронтенде
+(последнее слово, фраза, предложение ИЛИ специальный fallback-блок).
+raw — новый сырой ASR-текст.
+
+ВАЖНО:
+— Ты НЕ переписываешь и НЕ пересобираешь прошлый текст.
+— Ты НЕ цензор и НЕ оцениваешь «качество» или «смысл».
+— Даже странный, кривой или обрывочный текст — это ТЕКСТ.
+— ЗАПРЕЩЕНО возвращать пустую строку, если raw содержит речь.
+— previous может быть обычным текстом ИЛИ fallback-блоком с многоточием.
+— Если previous — fallback-блок, это НЕ причина молчать.
+
+ПРИОРИТЕТЫ (СТРОГО):
+1) Очеловечивание raw.
+2) Совместимость с previous.
+Очеловечивание ВСЕГДА важнее.
+
+ЧТО ТЫ ДЕЛАЕШЬ:
+
+1) Очеловечивание
+— Преврати raw в читаемый человеческий текст.
+— НЕ меняй смысл и НЕ перефразируй свободно.
+— Если в raw есть РЕЧЬ, результат ОБЯЗАН быть непустым.
+
+2) Продолжение текста
+— Если previous — обычный текст:
+  • верни фрагмент, который логично ПРОДОЛЖАЕТ previous.
+  • разрешено выбрать регистр букв и перенос строки.
+— Если previous — fallback-блок с многоточием:
+  • НЕ пытайся с ним состыковываться,
+  • просто верни очеловеченный результат raw.
+
+3) Перекрытие
+— Удаляй ТОЛЬКО прямое текстовое перекрытие
+  (буквальное или почти буквальное повторение конца previous).
+— Смысловое сходство НЕ является перекрытием.
+— Если raw содержит речь — текст НУЖНО вернуть.
+
+4) Fallback (ИСПОЛЬЗУЙ ТОЛЬКО ЕСЛИ НЕЛЬЗЯ ИНАЧЕ)
+— Если raw содержит речь, но после удаления ПРЯМОГО перекрытия
+  невозможно вернуть ни одного слова,
+  верни следующий формат:
+
+\n\n...
+(КРАТКОЕ ОБЪЯСНЕНИЕ ПРИЧИНЫ: перекрытие, обрыв фразы, шум, и т.п.)
+
+— Причину формулируй по фактической ситуации.
+— Если previous уже был fallback-блоком,
+  это НЕ запрещает вернуть нормальный текст при наличии речи.
+
+ПРАВИЛА:
+— previous никогда не переписывай.
+— previous никогда не возвращай.
+— Не объясняй свои действия вне указанного формата.
+— Верни один цельный фрагмент текста.
+`
+
+// glossaryPrompt — блок с правильными написаниями, дописывается к system prompt
+func glossaryPrompt(terms []models.GlossaryTerm) string {
+	if len(terms) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\nГЛОССАРИЙ (имена и термины пиши ТОЛЬКО так):\n")
+	for _, t := range terms {
+		spelling := t.Spelling
+		if spelling == "" {
+			spelling = t.Term
+		}
+		sb.WriteString("— ")
+		sb.WriteString(sanitize(spelling))
+		if len(t.Aliases) > 0 {
+			sb.WriteString(" (может быть распознано как: ")
+			sb.WriteString(sanitize(strings.Join(t.Aliases, ", ")))
+			sb.WriteString(")")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// sessionPrompt — указания конкретной сессии (из расписания и т.п.)
+func sessionPrompt(prompt string) string {
+	prompt = strings.TrimSpace(prompt)
+	if prompt == "" {
+		return ""
+	}
+	return "\nУКАЗАНИЯ ДЛЯ ЭТОЙ ТРАНСЛЯЦИИ:\n" + sanitize(prompt) + "\n"
+}
+
+func completionMaxTokens(req ports.CompletionRequest) int {
+	if req.MaxTokens > 0 {
+		return req.MaxTokens
+	}
+	return 2000
+}
+
+func responseFormat(req ports.CompletionRequest) *orResponseFormat {
+	if !req.JSON {
+		return nil
+	}
+	return &orResponseFormat{Type: "json_object"}
+}
+
+func chunkUserPrompt(prev, raw string) string {
+	return fmt.Sprintf("Previous:\n%s\n\nRaw:\n%s", prev, raw)
+}
+
+// recordLLMUsage — дописывает scope из контекста и пишет запись в ledger
+func recordLLMUsage(ctx context.Context, usage ports.UsageRecorder, rec *models.UsageRecord) {
+	if usage == nil {
+		return
+	}
+
+	scope := ports.UsageScopeFrom(ctx)
+	rec.MediaID = scope.MediaID
+	rec.ChunkNumber = scope.ChunkNumber
+	rec.User = scope.User
+
+	if err := usage.InsertUsage(context.WithoutCancel(ctx), rec); err != nil {
+		log.Printf("[USAGE][ERR] %s media=%d chunk=%d err=%v",
+			rec.Provider, scope.MediaID, scope.ChunkNumber, err)
+	}
+}
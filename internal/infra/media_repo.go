@@ -9,6 +9,7 @@ import (
 
 	"github.com/Vovarama1992/journalist/internal/models"
 	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -137,21 +138,42 @@ func (r *PostgresMediaRepo) GetLastCompletedChunk(ctx context.Context, mediaID i
 	return &c, nil
 }
 
+const mediaColumns = `
+	id, source_url, storage_url, media_type, created_at,
+	title, uploader, duration_sec, is_live, live_status, thumbnail_url, published_at,
+	audio_filter, finished_at, end_reason
+`
+
+func scanMedia(row pgx.Row, m *models.Media) error {
+	return row.Scan(
+		&m.ID,
+		&m.SourceURL,
+		&m.StorageURL,
+		&m.Type,
+		&m.CreatedAt,
+		&m.Title,
+		&m.Uploader,
+		&m.DurationSec,
+		&m.IsLive,
+		&m.LiveStatus,
+		&m.ThumbnailURL,
+		&m.PublishedAt,
+		&m.AudioFilter,
+		&m.FinishedAt,
+		&m.EndReason,
+	)
+}
+
 func (r *PostgresMediaRepo) GetMediaByID(ctx context.Context, id int) (*models.Media, error) {
 	query := `
-		SELECT id, source_url, media_type, created_at
+		SELECT ` + mediaColumns + `
 		FROM media
 		WHERE id = $1
 	`
 
 	var m models.Media
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&m.ID,
-		&m.SourceURL,
-		&m.Type,
-		&m.CreatedAt,
-	)
+	err := scanMedia(r.pool.QueryRow(ctx, query, id), &m)
 	if err != nil {
 		if err.Error() == "no rows in result set" {
 			return nil, nil
@@ -162,6 +184,51 @@ func (r *PostgresMediaRepo) GetMediaByID(ctx context.Context, id int) (*models.M
 	return &m, nil
 }
 
+func (r *PostgresMediaRepo) ListMedia(ctx context.Context, limit, offset int) ([]models.Media, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+mediaColumns+`
+		FROM media
+		ORDER BY id DESC
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list media: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.Media{}
+	for rows.Next() {
+		var m models.Media
+		if err := scanMedia(rows, &m); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresMediaRepo) UpdateMediaMeta(ctx context.Context, mediaID int, meta *models.MediaMeta) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE media
+		SET title = $1, uploader = $2, duration_sec = $3, is_live = $4,
+		    live_status = $5, thumbnail_url = $6, published_at = $7
+		WHERE id = $8
+	`, meta.Title, meta.Uploader, meta.DurationSec, meta.IsLive,
+		meta.LiveStatus, meta.ThumbnailURL, meta.PublishedAt, mediaID)
+	if err != nil {
+		return fmt.Errorf("update media meta: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresMediaRepo) SetMediaStorage(ctx context.Context, mediaID int, url string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE media SET storage_url = $1 WHERE id = $2`, url, mediaID)
+	if err != nil {
+		return fmt.Errorf("set media storage: %w", err)
+	}
+	return nil
+}
+
 func (r *PostgresMediaRepo) GetMediaHistory(ctx context.Context, mediaID int) (string, error) {
 	rows, err := r.pool.Query(ctx,
 		`SELECT COALESCE(text, '') 
@@ -190,33 +257,170 @@ func (r *PostgresMediaRepo) GetMediaHistory(ctx context.Context, mediaID int) (s
 	return strings.TrimSpace(sb.String()), nil
 }
 
-func (r *PostgresMediaRepo) InsertPendingChunk(
+const chunkColumns = `
+	id, media_id, chunk_number, COALESCE(text, ''), COALESCE(raw_text, ''),
+	COALESCE(file_path, ''), status,
+	COALESCE(captured_at, created_at, now()), offset_ms, duration_ms,
+	COALESCE(storage_url, ''),
+	COALESCE(audio_filter, ''), rms_before_db, rms_after_db
+`
+
+func scanChunk(row pgx.Row, c *models.MediaChunk) error {
+	return row.Scan(
+		&c.ID,
+		&c.MediaID,
+		&c.ChunkNumber,
+		&c.Text,
+		&c.RawText,
+		&c.FilePath,
+		&c.Status,
+		&c.CapturedAt,
+		&c.OffsetMs,
+		&c.DurationMs,
+		&c.StorageURL,
+		&c.AudioFilter,
+		&c.RMSBeforeDB,
+		&c.RMSAfterDB,
+	)
+}
+
+// ListCompletedChunks — все готовые непустые чанки по порядку
+func (r *PostgresMediaRepo) ListCompletedChunks(ctx context.Context, mediaID int) ([]models.MediaChunk, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+chunkColumns+`
+		FROM media_chunk
+		WHERE media_id = $1
+		  AND status = 'done'
+		  AND text IS NOT NULL
+		  AND text <> ''
+		ORDER BY chunk_number ASC
+	`, mediaID)
+	if err != nil {
+		return nil, fmt.Errorf("list completed chunks: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.MediaChunk
+	for rows.Next() {
+		var c models.MediaChunk
+		if err := scanChunk(rows, &c); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+
+	return out, rows.Err()
+}
+
+func (r *PostgresMediaRepo) GetChunk(ctx context.Context, mediaID, chunkNumber int) (*models.MediaChunk, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+chunkColumns+`
+		FROM media_chunk
+		WHERE media_id = $1 AND chunk_number = $2
+	`, mediaID, chunkNumber)
+
+	var c models.MediaChunk
+	if err := scanChunk(row, &c); err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get chunk: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *PostgresMediaRepo) SearchChunks(
 	ctx context.Context,
 	mediaID int,
-	filePath string,
-) (*models.MediaChunk, error) {
+	query string,
+	limit int,
+) ([]models.MediaChunk, error) {
+
+	// plainto_tsquery соединяет слова через &, для вопроса нужно «любое из»
+	rows, err := r.pool.Query(ctx, `
+		WITH q AS (
+			SELECT NULLIF(replace(plainto_tsquery('russian', $2)::text, '&', '|'), '')::tsquery AS tsq
+		)
+		SELECT `+chunkColumns+`
+		FROM media_chunk, q
+		WHERE media_id = $1
+		  AND status = 'done'
+		  AND text IS NOT NULL
+		  AND text <> ''
+		  AND to_tsvector('russian', text) @@ q.tsq
+		ORDER BY ts_rank(to_tsvector('russian', text), q.tsq) DESC, chunk_number DESC
+		LIMIT $3
+	`, mediaID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search chunks: %w", err)
+	}
+	defer rows.Close()
 
-	query := `
-		INSERT INTO media_chunk (media_id, chunk_number, file_path, status)
+	var out []models.MediaChunk
+	for rows.Next() {
+		var c models.MediaChunk
+		if err := scanChunk(rows, &c); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+
+	return out, rows.Err()
+}
+
+// AllocChunkNumber — строка media_chunk_counter под блокировкой строки:
+// параллельные вызовы получают разные номера; первая строка — после уже
+// существующих чанков
+func (r *PostgresMediaRepo) AllocChunkNumber(ctx context.Context, mediaID int) (int, error) {
+	var n int
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO media_chunk_counter (media_id, last_number)
 		VALUES (
 			$1,
-			COALESCE((
-				SELECT MAX(chunk_number)+1 FROM media_chunk WHERE media_id=$1
-			), 1),
-			$2,
-			'pending'
+			COALESCE((SELECT MAX(chunk_number) FROM media_chunk WHERE media_id=$1), 0) + 1
 		)
-		RETURNING id, chunk_number
+		ON CONFLICT (media_id) DO UPDATE
+		SET last_number = media_chunk_counter.last_number + 1
+		RETURNING last_number
+	`, mediaID).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("alloc chunk number: %w", err)
+	}
+	return n, nil
+}
+
+// InsertPendingChunk — chunk.MediaID, FilePath и тайминги захвата заполняет вызывающий;
+// номер лучше взять AllocChunkNumber в момент захвата, иначе — здесь
+func (r *PostgresMediaRepo) InsertPendingChunk(
+	ctx context.Context,
+	chunk *models.MediaChunk,
+) (*models.MediaChunk, error) {
+
+	c := *chunk
+	if c.ChunkNumber == 0 {
+		n, err := r.AllocChunkNumber(ctx, c.MediaID)
+		if err != nil {
+			return nil, err
+		}
+		c.ChunkNumber = n
+	}
+
+	query := `
+		INSERT INTO media_chunk (
+			media_id, chunk_number, file_path, status,
+			captured_at, offset_ms, duration_ms
+		)
+		VALUES ($1, $2, $3, 'pending', $4, $5, $6)
+		RETURNING id
 	`
 
-	var c models.MediaChunk
-	err := r.pool.QueryRow(ctx, query, mediaID, filePath).Scan(&c.ID, &c.ChunkNumber)
+	err := r.pool.QueryRow(ctx, query,
+		c.MediaID, c.ChunkNumber, c.FilePath, c.CapturedAt, c.OffsetMs, c.DurationMs,
+	).Scan(&c.ID)
 	if err != nil {
 		return nil, fmt.Errorf("insert pending chunk: %w", err)
 	}
 
-	c.MediaID = mediaID
-	c.FilePath = filePath
 	c.Status = "pending"
 	return &c, nil
 }
@@ -228,6 +432,7 @@ func (r *PostgresMediaRepo) CompleteChunk(
 	ctx context.Context,
 	mediaID int,
 	chunkNumber int,
+	raw string,
 	text string,
 ) error {
 
@@ -238,10 +443,10 @@ func (r *PostgresMediaRepo) CompleteChunk(
 
 	query := `
 		UPDATE media_chunk
-		SET text = $1, status = 'done'
-		WHERE media_id = $2 AND chunk_number = $3
+		SET raw_text = $1, text = $2, status = 'done'
+		WHERE media_id = $3 AND chunk_number = $4
 	`
-	_, err := r.pool.Exec(ctx, query, text, mediaID, chunkNumber)
+	_, err := r.pool.Exec(ctx, query, raw, text, mediaID, chunkNumber)
 	if err != nil {
 		return err
 	}
@@ -252,3 +457,55 @@ func (r *PostgresMediaRepo) CompleteChunk(
 
 	return nil
 }
+
+// SetChunkStorage — WAV чанка в архиве; локальный PCM после этого не нужен
+func (r *PostgresMediaRepo) SetChunkStorage(ctx context.Context, mediaID, chunkNumber int, url string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE media_chunk
+		SET storage_url = $1, file_path = NULL
+		WHERE media_id = $2 AND chunk_number = $3
+	`, url, mediaID, chunkNumber)
+	if err != nil {
+		return fmt.Errorf("set chunk storage: %w", err)
+	}
+	return nil
+}
+
+// SetMediaFilter — пресет предобработки; идущая сессия подхватит со следующего окна
+func (r *PostgresMediaRepo) SetMediaFilter(ctx context.Context, mediaID int, preset string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE media
+		SET audio_filter = $1
+		WHERE id = $2
+	`, preset, mediaID)
+	if err != nil {
+		return fmt.Errorf("set media filter: %w", err)
+	}
+	return nil
+}
+
+// SetChunkLevels — пресет и RMS до / после фильтров
+func (r *PostgresMediaRepo) SetChunkLevels(ctx context.Context, mediaID, chunkNumber int, preset string, before, after float64) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE media_chunk
+		SET audio_filter = $1, rms_before_db = $2, rms_after_db = $3
+		WHERE media_id = $4 AND chunk_number = $5
+	`, preset, before, after, mediaID, chunkNumber)
+	if err != nil {
+		return fmt.Errorf("set chunk levels: %w", err)
+	}
+	return nil
+}
+
+// FinishMedia — эфир / запись завершены; повторный вызов время не сдвигает
+func (r *PostgresMediaRepo) FinishMedia(ctx context.Context, mediaID int, reason string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE media
+		SET finished_at = COALESCE(finished_at, now()), end_reason = $1
+		WHERE id = $2
+	`, reason, mediaID)
+	if err != nil {
+		return fmt.Errorf("finish media: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package infra
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/migrations"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// testPool — TEST_DATABASE_URL (отдельная БД!) со схемой из migrations; нет — тест пропускается
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	migrator, err := NewMigrator(pool, migrations.FS)
+	if err != nil {
+		t.Fatalf("migrations: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	return pool
+}
+
+func testMedia(t *testing.T, pool *pgxpool.Pool) *models.Media {
+	t.Helper()
+
+	media, err := NewPostgresMediaRepo(pool).InsertMedia(context.Background(), &models.Media{
+		SourceURL: "test://" + t.Name(),
+		Type:      "audio",
+	})
+	if err != nil {
+		t.Fatalf("insert media: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM media WHERE id = $1`, media.ID)
+	})
+	return media
+}
+
+// assertSequence — ровно 1..n, без повторов
+func assertSequence(t *testing.T, got []int, n int) {
+	t.Helper()
+
+	sort.Ints(got)
+	if len(got) != n {
+		t.Fatalf("got %d numbers, want %d", len(got), n)
+	}
+	for i, v := range got {
+		if v != i+1 {
+			t.Fatalf("numbers = %v, want 1..%d", got, n)
+		}
+	}
+}
+
+const hammer = 64
+
+func TestAllocChunkNumberConcurrent(t *testing.T) {
+	pool := testPool(t)
+	repo := NewPostgresMediaRepo(pool)
+	media := testMedia(t, pool)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		nums []int
+	)
+	start := make(chan struct{})
+
+	for i := 0; i < hammer; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			n, err := repo.AllocChunkNumber(context.Background(), media.ID)
+			if err != nil {
+				t.Errorf("alloc: %v", err)
+				return
+			}
+			mu.Lock()
+			nums = append(nums, n)
+			mu.Unlock()
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assertSequence(t, nums, hammer)
+}
+
+func TestInsertPendingChunkConcurrent(t *testing.T) {
+	pool := testPool(t)
+	repo := NewPostgresMediaRepo(pool)
+	media := testMedia(t, pool)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		nums []int
+	)
+	start := make(chan struct{})
+
+	for i := 0; i < hammer; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			// ChunkNumber == 0 → номер выдаёт сам InsertPendingChunk
+			c, err := repo.InsertPendingChunk(context.Background(), &models.MediaChunk{
+				MediaID:    media.ID,
+				FilePath:   "/dev/null",
+				CapturedAt: time.Now(),
+			})
+			if err != nil {
+				t.Errorf("insert pending (unique violation?): %v", err)
+				return
+			}
+			mu.Lock()
+			nums = append(nums, c.ChunkNumber)
+			mu.Unlock()
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assertSequence(t, nums, hammer)
+
+	var rows int
+	if err := pool.QueryRow(context.Background(),
+		`SELECT count(*) FROM media_chunk WHERE media_id = $1`, media.ID,
+	).Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if rows != hammer {
+		t.Fatalf("rows = %d, want %d", rows, hammer)
+	}
+}
+
+// номер выдан в момент захвата — вставка в обратном порядке его не меняет
+func TestInsertPendingChunkKeepsCaptureOrder(t *testing.T) {
+	pool := testPool(t)
+	repo := NewPostgresMediaRepo(pool)
+	media := testMedia(t, pool)
+	ctx := context.Background()
+
+	base := time.Now()
+	captured := make([]*models.MediaChunk, 8)
+	for i := range captured {
+		n, err := repo.AllocChunkNumber(ctx, media.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		captured[i] = &models.MediaChunk{
+			MediaID:     media.ID,
+			ChunkNumber: n,
+			FilePath:    "/dev/null",
+			CapturedAt:  base.Add(time.Duration(i) * 10 * time.Second),
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := len(captured) - 1; i >= 0; i-- {
+		wg.Add(1)
+		go func(c *models.MediaChunk) {
+			defer wg.Done()
+			if _, err := repo.InsertPendingChunk(ctx, c); err != nil {
+				t.Errorf("insert pending: %v", err)
+			}
+		}(captured[i])
+	}
+	wg.Wait()
+
+	rows, err := pool.Query(ctx, `
+		SELECT chunk_number, captured_at FROM media_chunk
+		WHERE media_id = $1 ORDER BY chunk_number
+	`, media.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var prev time.Time
+	count := 0
+	for rows.Next() {
+		var n int
+		var at time.Time
+		if err := rows.Scan(&n, &at); err != nil {
+			t.Fatal(err)
+		}
+		if at.Before(prev) {
+			t.Fatalf("chunk %d captured at %s before previous %s", n, at, prev)
+		}
+		prev = at
+		count++
+	}
+	if count != len(captured) {
+		t.Fatalf("rows = %d, want %d", count, len(captured))
+	}
+}
+
+// 018 заводит счётчик по уже существующим чанкам; media без строки
+// счётчика (чанки после миграции, но до первого Alloc) продолжает с MAX+1
+func TestChunkCounterSeed(t *testing.T) {
+	pool := testPool(t)
+	repo := NewPostgresMediaRepo(pool)
+	ctx := context.Background()
+
+	seeded := testMedia(t, pool)
+	unseeded := testMedia(t, pool)
+
+	for _, media := range []*models.Media{seeded, unseeded} {
+		for n := 1; n <= 5; n++ {
+			if err := repo.InsertChunk(ctx, &models.MediaChunk{MediaID: media.ID, ChunkNumber: n}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	sql, err := fs.ReadFile(migrations.FS, "018_chunk_counter.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM media_chunk_counter WHERE media_id = ANY($1)`,
+		[]int{seeded.ID, unseeded.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Exec(ctx, string(sql)); err != nil {
+		t.Fatalf("re-run 018: %v", err)
+	}
+	// вторая — как будто чанки появились после миграции
+	if _, err := pool.Exec(ctx, `DELETE FROM media_chunk_counter WHERE media_id = $1`, unseeded.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var last int
+	if err := pool.QueryRow(ctx,
+		`SELECT last_number FROM media_chunk_counter WHERE media_id = $1`, seeded.ID,
+	).Scan(&last); err != nil {
+		t.Fatalf("seeded counter: %v", err)
+	}
+	if last != 5 {
+		t.Fatalf("seeded last_number = %d, want 5", last)
+	}
+
+	for _, media := range []*models.Media{seeded, unseeded} {
+		n, err := repo.AllocChunkNumber(ctx, media.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 6 {
+			t.Fatalf("media %d: next = %d, want 6", media.ID, n)
+		}
+	}
+}
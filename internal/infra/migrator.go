@@ -0,0 +1,275 @@
+package infra
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrateLockKey — pg_advisory_lock: два инстанса не накатывают схему одновременно
+const migrateLockKey = 7_318_204_001
+
+// Migration — NNN_name.sql и (не обязательно) NNN_name.down.sql
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // sha256 up-файла
+}
+
+// MigrationStatus — строка `migrate status`
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+	Modified  bool // файл изменён после применения
+}
+
+// Migrator — миграции из fs (go:embed), учёт в schema_migrations;
+// каждая миграция — в своей транзакции вместе с записью о ней
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+func NewMigrator(pool *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{pool: pool, migrations: migrations}, nil
+}
+
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, file := range files {
+		base, down := strings.CutSuffix(file, ".down.sql")
+		if !down {
+			base = strings.TrimSuffix(file, ".sql")
+		}
+
+		num, name, ok := strings.Cut(base, "_")
+		version, err := strconv.Atoi(num)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must be NNN_name.sql", file)
+		}
+
+		b, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration %03d: two names %q and %q", version, m.Name, name)
+		}
+
+		if down {
+			m.Down = string(b)
+			continue
+		}
+		sum := sha256.Sum256(b)
+		m.Up, m.Checksum = string(b), hex.EncodeToString(sum[:])
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %03d_%s: down without up", m.Version, m.Name)
+		}
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+type appliedMigration struct {
+	checksum  string
+	appliedAt time.Time
+}
+
+// Up — все ещё не применённые по порядку; изменённый после применения
+// файл — ошибка до того, как что-то накатится
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	n := 0
+	err := m.locked(ctx, func(conn *pgxpool.Conn, applied map[int]appliedMigration) error {
+		known := make(map[int]bool, len(m.migrations))
+		for _, mg := range m.migrations {
+			known[mg.Version] = true
+			if a, ok := applied[mg.Version]; ok && a.checksum != mg.Checksum {
+				return fmt.Errorf("migration %03d_%s: checksum mismatch — applied file was edited, add a new migration instead",
+					mg.Version, mg.Name)
+			}
+		}
+		for v := range applied {
+			if !known[v] {
+				log.Printf("[MIGRATE][WARN] version %03d is applied but unknown to this binary", v)
+			}
+		}
+
+		for _, mg := range m.migrations {
+			if _, ok := applied[mg.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, mg); err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
+// Down — откат steps последних применённых (по убыванию версии)
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	n := 0
+	err := m.locked(ctx, func(conn *pgxpool.Conn, applied map[int]appliedMigration) error {
+		for i := len(m.migrations) - 1; i >= 0 && n < steps; i-- {
+			mg := m.migrations[i]
+			if _, ok := applied[mg.Version]; !ok {
+				continue
+			}
+			if mg.Down == "" {
+				return fmt.Errorf("migration %03d_%s: no down file", mg.Version, mg.Name)
+			}
+			if err := m.revert(ctx, conn, mg); err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var out []MigrationStatus
+	err := m.locked(ctx, func(_ *pgxpool.Conn, applied map[int]appliedMigration) error {
+		for _, mg := range m.migrations {
+			st := MigrationStatus{Version: mg.Version, Name: mg.Name}
+			if a, ok := applied[mg.Version]; ok {
+				at := a.appliedAt
+				st.AppliedAt = &at
+				st.Modified = a.checksum != mg.Checksum
+			}
+			out = append(out, st)
+		}
+		return nil
+	})
+	return out, err
+}
+
+// locked — одно соединение под advisory lock; schema_migrations создаётся при первом запуске
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgxpool.Conn, applied map[int]appliedMigration) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate: acquire conn: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrateLockKey); err != nil {
+		return fmt.Errorf("migrate: lock: %w", err)
+	}
+	defer conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, migrateLockKey)
+
+	_, err = conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INT PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("migrate: create schema_migrations: %w", err)
+	}
+
+	rows, err := conn.Query(ctx, `SELECT version, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("migrate: list applied: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]appliedMigration)
+	for rows.Next() {
+		var v int
+		var a appliedMigration
+		if err := rows.Scan(&v, &a.checksum, &a.appliedAt); err != nil {
+			return err
+		}
+		applied[v] = a
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return fn(conn, applied)
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, mg Migration) error {
+	start := time.Now()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, mg.Up); err != nil {
+		return fmt.Errorf("migration %03d_%s: %w", mg.Version, mg.Name, err)
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO schema_migrations (version, name, checksum)
+		VALUES ($1, $2, $3)
+	`, mg.Version, mg.Name, mg.Checksum)
+	if err != nil {
+		return fmt.Errorf("migration %03d_%s: record: %w", mg.Version, mg.Name, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("migration %03d_%s: commit: %w", mg.Version, mg.Name, err)
+	}
+
+	log.Printf("[MIGRATE][UP] %03d_%s dur=%s", mg.Version, mg.Name, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+func (m *Migrator) revert(ctx context.Context, conn *pgxpool.Conn, mg Migration) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, mg.Down); err != nil {
+		return fmt.Errorf("migration %03d_%s down: %w", mg.Version, mg.Name, err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mg.Version); err != nil {
+		return fmt.Errorf("migration %03d_%s down: record: %w", mg.Version, mg.Name, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("migration %03d_%s down: commit: %w", mg.Version, mg.Name, err)
+	}
+
+	log.Printf("[MIGRATE][DOWN] %03d_%s", mg.Version, mg.Name)
+	return nil
+}
@@ -0,0 +1,100 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresQuoteRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresQuoteRepo(pool *pgxpool.Pool) ports.QuoteRepository {
+	return &PostgresQuoteRepo{pool: pool}
+}
+
+const quoteColumns = `
+	id, media_id, chunk_number, speaker, text, verbatim, offset_ms, duration_ms, created_at
+`
+
+func (r *PostgresQuoteRepo) ReplaceQuotes(ctx context.Context, mediaID int, quotes []models.Quote) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM media_quote WHERE media_id = $1`, mediaID); err != nil {
+		return fmt.Errorf("delete quotes: %w", err)
+	}
+
+	for i := range quotes {
+		q := &quotes[i]
+		q.MediaID = mediaID
+		err := tx.QueryRow(ctx, `
+			INSERT INTO media_quote (media_id, chunk_number, speaker, text, verbatim, offset_ms, duration_ms)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, created_at
+		`, q.MediaID, q.ChunkNumber, q.Speaker, q.Text, q.Verbatim, q.OffsetMs, q.DurationMs,
+		).Scan(&q.ID, &q.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("insert quote: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresQuoteRepo) ListQuotes(ctx context.Context, mediaID int) ([]models.Quote, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+quoteColumns+`
+		FROM media_quote
+		WHERE media_id = $1
+		ORDER BY offset_ms ASC, id ASC
+	`, mediaID)
+	if err != nil {
+		return nil, fmt.Errorf("list quotes: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.Quote{}
+	for rows.Next() {
+		var q models.Quote
+		if err := scanQuote(rows, &q); err != nil {
+			return nil, err
+		}
+		out = append(out, q)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresQuoteRepo) GetQuote(ctx context.Context, id int) (*models.Quote, error) {
+	var q models.Quote
+	row := r.pool.QueryRow(ctx, `SELECT `+quoteColumns+` FROM media_quote WHERE id = $1`, id)
+	if err := scanQuote(row, &q); err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get quote: %w", err)
+	}
+	return &q, nil
+}
+
+func scanQuote(row pgx.Row, q *models.Quote) error {
+	return row.Scan(
+		&q.ID,
+		&q.MediaID,
+		&q.ChunkNumber,
+		&q.Speaker,
+		&q.Text,
+		&q.Verbatim,
+		&q.OffsetMs,
+		&q.DurationMs,
+		&q.CreatedAt,
+	)
+}
@@ -0,0 +1,285 @@
+package infra
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ========================================================================
+// POSTGRES STORAGE
+// ========================================================================
+
+type PostgresResultCache struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresResultCache(pool *pgxpool.Pool) ports.ResultCache {
+	return &PostgresResultCache{pool: pool}
+}
+
+func (c *PostgresResultCache) Get(ctx context.Context, key string) (string, bool, error) {
+	var value string
+	err := c.pool.QueryRow(ctx, `
+		SELECT value FROM result_cache
+		WHERE key = $1 AND expires_at > now()
+	`, key).Scan(&value)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("cache get: %w", err)
+	}
+	return value, true, nil
+}
+
+func (c *PostgresResultCache) Set(ctx context.Context, key, kind, value string, ttl time.Duration) error {
+	_, err := c.pool.Exec(ctx, `
+		INSERT INTO result_cache (key, kind, value, expires_at)
+		VALUES ($1, $2, $3, now() + $4::interval)
+		ON CONFLICT (key) DO UPDATE
+		SET value = EXCLUDED.value, created_at = now(), expires_at = EXCLUDED.expires_at
+	`, key, kind, value, fmt.Sprintf("%d seconds", int64(ttl.Seconds())))
+	if err != nil {
+		return fmt.Errorf("cache set: %w", err)
+	}
+	return nil
+}
+
+func (c *PostgresResultCache) PurgeExpired(ctx context.Context) (int64, error) {
+	tag, err := c.pool.Exec(ctx, `DELETE FROM result_cache WHERE expires_at <= now()`)
+	if err != nil {
+		return 0, fmt.Errorf("cache purge: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ========================================================================
+// METRICS
+// ========================================================================
+
+type cacheCounters struct {
+	hits, misses, errors atomic.Int64
+}
+
+type CacheMetrics struct {
+	mu    sync.Mutex
+	kinds map[string]*cacheCounters
+}
+
+func NewCacheMetrics() *CacheMetrics {
+	return &CacheMetrics{kinds: make(map[string]*cacheCounters)}
+}
+
+func (m *CacheMetrics) counters(kind string) *cacheCounters {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.kinds[kind]
+	if !ok {
+		c = &cacheCounters{}
+		m.kinds[kind] = c
+	}
+	return c
+}
+
+func (m *CacheMetrics) Stats() []models.CacheStat {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]models.CacheStat, 0, len(m.kinds))
+	for kind, c := range m.kinds {
+		out = append(out, models.CacheStat{
+			Kind:   kind,
+			Hits:   c.hits.Load(),
+			Misses: c.misses.Load(),
+			Errors: c.errors.Load(),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Kind < out[j].Kind })
+	return out
+}
+
+// ========================================================================
+// STT DECORATOR
+// ========================================================================
+
+type CachedSTT struct {
+	inner   ports.STTService
+	cache   ports.ResultCache
+	ttl     time.Duration
+	metrics *cacheCounters
+}
+
+func NewCachedSTT(inner ports.STTService, cache ports.ResultCache, ttl time.Duration, metrics *CacheMetrics) ports.STTService {
+	return &CachedSTT{
+		inner:   inner,
+		cache:   cache,
+		ttl:     ttl,
+		metrics: metrics.counters("stt"),
+	}
+}
+
+type cachedSTTValue struct {
+	Text string `json:"text"`
+	Raw  string `json:"raw"`
+}
+
+func (c *CachedSTT) Recognize(ctx context.Context, wav []byte, opts ports.RecognizeOptions) (string, []byte, error) {
+	// выбранная дорожка / канал уже в самом PCM; язык распознавания — нет,
+	// поэтому он в ключе. Hints — нет: Yandex v1 их не принимает, и правка
+	// глоссария не должна заново оплачивать распознавание того же звука
+	parts := []any{wav}
+	if opts.Language != "" {
+		parts = append(parts, opts.Language)
+	}
+	key, err := cacheKey("stt", parts...)
+	if err != nil {
+		// ключ не построить — без кэша, чем с возможной коллизией
+		c.metrics.errors.Add(1)
+		log.Printf("[CACHE][STT][ERR] key: %v", err)
+		return c.inner.Recognize(ctx, wav, opts)
+	}
+
+	if v, ok := c.lookup(ctx, key); ok {
+		return v.Text, []byte(v.Raw), nil
+	}
+
+	text, raw, err := c.inner.Recognize(ctx, wav, opts)
+	if err != nil {
+		return text, raw, err
+	}
+
+	b, _ := json.Marshal(cachedSTTValue{Text: text, Raw: string(raw)})
+	if err := c.cache.Set(context.WithoutCancel(ctx), key, "stt", string(b), c.ttl); err != nil {
+		c.metrics.errors.Add(1)
+		log.Printf("[CACHE][STT][ERR] set: %v", err)
+	}
+	return text, raw, nil
+}
+
+func (c *CachedSTT) lookup(ctx context.Context, key string) (cachedSTTValue, bool) {
+	var v cachedSTTValue
+
+	s, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		c.metrics.errors.Add(1)
+		log.Printf("[CACHE][STT][ERR] get: %v", err)
+	}
+	if !ok || json.Unmarshal([]byte(s), &v) != nil {
+		c.metrics.misses.Add(1)
+		return v, false
+	}
+
+	c.metrics.hits.Add(1)
+	log.Printf("[CACHE][STT][HIT] key=%s", key[:16])
+	return v, true
+}
+
+// ========================================================================
+// LLM DECORATOR
+// ========================================================================
+
+// CachedGPT кэширует только ProcessChunk: ответ S5 детерминирован входом.
+// Complete не кэшируется — регенерация статьи обязана давать новый результат.
+type CachedGPT struct {
+	ports.LLMService
+
+	inner   ports.LLMClient
+	cache   ports.ResultCache
+	model   string
+	ttl     time.Duration
+	metrics *cacheCounters
+}
+
+func NewCachedGPT(
+	inner ports.LLMClient,
+	cache ports.ResultCache,
+	model string,
+	ttl time.Duration,
+	metrics *CacheMetrics,
+) ports.LLMClient {
+	return &CachedGPT{
+		LLMService: inner,
+		inner:      inner,
+		cache:      cache,
+		model:      model,
+		ttl:        ttl,
+		metrics:    metrics.counters("llm"),
+	}
+}
+
+func (c *CachedGPT) ProcessChunk(ctx context.Context, prev, raw string, opts ports.ChunkOptions) (string, error) {
+	glossary, _ := json.Marshal(opts.Glossary)
+	parts := []any{[]byte(prev), []byte(raw), ChunkPromptVersion, c.model, string(glossary)}
+	if opts.Prompt != "" {
+		parts = append(parts, opts.Prompt)
+	}
+	key, err := cacheKey("llm", parts...)
+	if err != nil {
+		c.metrics.errors.Add(1)
+		log.Printf("[CACHE][LLM][ERR] key: %v", err)
+		return c.inner.ProcessChunk(ctx, prev, raw, opts)
+	}
+
+	s, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		c.metrics.errors.Add(1)
+		log.Printf("[CACHE][LLM][ERR] get: %v", err)
+	}
+	if ok {
+		c.metrics.hits.Add(1)
+		log.Printf("[CACHE][LLM][HIT] key=%s", key[:16])
+		return s, nil
+	}
+	c.metrics.misses.Add(1)
+
+	out, err := c.inner.ProcessChunk(ctx, prev, raw, opts)
+	if err != nil {
+		return out, err
+	}
+
+	if err := c.cache.Set(context.WithoutCancel(ctx), key, "llm", out, c.ttl); err != nil {
+		c.metrics.errors.Add(1)
+		log.Printf("[CACHE][LLM][ERR] set: %v", err)
+	}
+	return out, nil
+}
+
+// cacheKey — sha256 по частям с разделителем-длиной, чтобы "ab"+"c" ≠ "a"+"bc".
+// Часть неизвестного типа — ошибка: молча пропущенная дала бы общий ключ разным запросам
+func cacheKey(kind string, parts ...any) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(kind))
+
+	for _, p := range parts {
+		switch v := p.(type) {
+		case []byte:
+			fmt.Fprintf(h, "|%d|", len(v))
+			h.Write(v)
+		case string:
+			fmt.Fprintf(h, "|%d|", len(v))
+			h.Write([]byte(v))
+		case []string:
+			fmt.Fprintf(h, "|%d|", len(v))
+			for _, s := range v {
+				fmt.Fprintf(h, "%d:%s", len(s), s)
+			}
+		default:
+			return "", fmt.Errorf("cache key %s: unsupported part type %T", kind, p)
+		}
+	}
+
+	return kind + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,28 @@
+package infra
+
+import "testing"
+
+func TestCacheKey(t *testing.T) {
+	a, err := cacheKey("llm", "ab", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := cacheKey("llm", "a", "bc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b {
+		t.Fatal(`"ab"+"c" and "a"+"bc" share a key`)
+	}
+
+	s, _ := cacheKey("stt", []byte("pcm"), []string{"a", "b"})
+	bs, _ := cacheKey("stt", "pcm", []string{"a", "b"})
+	if s != bs {
+		t.Fatal("[]byte and string with the same content must share a key")
+	}
+
+	// неизвестный тип не пропускается молча: 1 и 2 дали бы один ключ
+	if _, err := cacheKey("llm", "x", 1); err == nil {
+		t.Fatal("int part: want error")
+	}
+}
@@ -0,0 +1,141 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresScheduleRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresScheduleRepo(pool *pgxpool.Pool) ports.ScheduleRepository {
+	return &PostgresScheduleRepo{pool: pool}
+}
+
+const scheduleColumns = `
+	id, url, room_id, user_name, language, prompt, start_at, max_duration_sec,
+	status, attempts, media_id, last_error, started_at, finished_at, created_at
+`
+
+func scanSchedule(row pgx.Row, s *models.ScheduledSession) error {
+	return row.Scan(
+		&s.ID,
+		&s.URL,
+		&s.RoomID,
+		&s.User,
+		&s.Language,
+		&s.Prompt,
+		&s.StartAt,
+		&s.MaxDurationSec,
+		&s.Status,
+		&s.Attempts,
+		&s.MediaID,
+		&s.LastError,
+		&s.StartedAt,
+		&s.FinishedAt,
+		&s.CreatedAt,
+	)
+}
+
+func (r *PostgresScheduleRepo) InsertSchedule(ctx context.Context, s *models.ScheduledSession) error {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO scheduled_session
+			(url, room_id, user_name, language, prompt, start_at, max_duration_sec, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`, s.URL, s.RoomID, s.User, s.Language, s.Prompt, s.StartAt, s.MaxDurationSec, s.Status,
+	).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert schedule: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresScheduleRepo) UpdateSchedule(ctx context.Context, s *models.ScheduledSession) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE scheduled_session
+		SET url = $1, room_id = $2, user_name = $3, language = $4, prompt = $5,
+		    start_at = $6, max_duration_sec = $7
+		WHERE id = $8
+	`, s.URL, s.RoomID, s.User, s.Language, s.Prompt, s.StartAt, s.MaxDurationSec, s.ID)
+	if err != nil {
+		return fmt.Errorf("update schedule: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresScheduleRepo) GetSchedule(ctx context.Context, id int) (*models.ScheduledSession, error) {
+	var s models.ScheduledSession
+	err := scanSchedule(r.pool.QueryRow(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM scheduled_session
+		WHERE id = $1
+	`, id), &s)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *PostgresScheduleRepo) ListSchedules(ctx context.Context, status string, limit, offset int) ([]models.ScheduledSession, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM scheduled_session
+		WHERE $1 = '' OR status = $1
+		ORDER BY start_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list schedules: %w", err)
+	}
+	return collectSchedules(rows)
+}
+
+func (r *PostgresScheduleRepo) ListActiveSchedules(ctx context.Context) ([]models.ScheduledSession, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM scheduled_session
+		WHERE status IN ('planned', 'waiting', 'running')
+		ORDER BY start_at ASC, id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list active schedules: %w", err)
+	}
+	return collectSchedules(rows)
+}
+
+func collectSchedules(rows pgx.Rows) ([]models.ScheduledSession, error) {
+	defer rows.Close()
+
+	out := []models.ScheduledSession{}
+	for rows.Next() {
+		var s models.ScheduledSession
+		if err := scanSchedule(rows, &s); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresScheduleRepo) SetScheduleState(ctx context.Context, s *models.ScheduledSession) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE scheduled_session
+		SET status = $1, attempts = $2, media_id = $3, last_error = $4,
+		    started_at = $5, finished_at = $6
+		WHERE id = $7
+	`, s.Status, s.Attempts, s.MediaID, s.LastError, s.StartedAt, s.FinishedAt, s.ID)
+	if err != nil {
+		return fmt.Errorf("set schedule state: %w", err)
+	}
+	return nil
+}
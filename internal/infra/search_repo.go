@@ -0,0 +1,160 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// маркеры совпадений из ts_headline: текст экранируется уже в Go,
+// потом маркеры становятся <mark> — HTML из самого текста не пройдёт
+const (
+	searchMarkStart = "\uE000"
+	searchMarkStop  = "\uE001"
+)
+
+var searchHeadlineOpts = `StartSel="` + searchMarkStart + `", StopSel="` + searchMarkStop +
+	`", MaxFragments=2, MaxWords=35, MinWords=12, FragmentDelimiter=" … "`
+
+type PostgresSearchRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresSearchRepo(pool *pgxpool.Pool) ports.TranscriptSearcher {
+	return &PostgresSearchRepo{pool: pool}
+}
+
+// Search — media_chunk.text_tsv (russian || english); запрос — websearch_to_tsquery
+// в обеих конфигурациях через OR. Исключения (-слово, -"фраза") отдельно: строка
+// не должна совпасть ни в одной конфигурации — иначе «-собянин» пропускал бы
+// «Собянина» через английскую половину, где слово не стеммится.
+// ts_headline — только для строк страницы
+func (r *PostgresSearchRepo) Search(ctx context.Context, f models.SearchFilter) ([]models.SearchHit, int, error) {
+	var mediaIDs []int
+	if len(f.MediaIDs) > 0 {
+		mediaIDs = f.MediaIDs
+	}
+
+	positive, excluded := splitExclusions(f.Query)
+
+	rows, err := r.pool.Query(ctx, `
+		WITH q AS (
+			SELECT websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1) AS tsq,
+			       websearch_to_tsquery('russian', $8) || websearch_to_tsquery('english', $8) AS neg
+		), hits AS (
+			SELECT c.media_id, c.chunk_number, c.offset_ms, c.text,
+			       ts_rank_cd(c.text_tsv, q.tsq) AS rank,
+			       count(*) OVER () AS total
+			FROM media_chunk c
+			JOIN media m ON m.id = c.media_id
+			CROSS JOIN q
+			WHERE c.status = 'done'
+			  AND c.text_tsv @@ q.tsq
+			  AND ($8 = '' OR NOT c.text_tsv @@ q.neg)
+			  AND ($2::int[] IS NULL OR c.media_id = ANY($2))
+			  AND ($3::timestamptz IS NULL OR COALESCE(m.published_at, m.created_at) >= $3)
+			  AND ($4::timestamptz IS NULL OR COALESCE(m.published_at, m.created_at) < $4)
+			ORDER BY rank DESC, c.media_id DESC, c.chunk_number
+			LIMIT $5 OFFSET $6
+		)
+		SELECT h.media_id, h.chunk_number, h.offset_ms, h.rank, h.total,
+		       ts_headline('russian', h.text, q.tsq, $7),
+		       m.source_url, m.created_at,
+		       m.title, m.uploader, m.duration_sec, m.is_live, m.live_status,
+		       m.thumbnail_url, m.published_at
+		FROM hits h
+		JOIN media m ON m.id = h.media_id
+		CROSS JOIN q
+		ORDER BY h.rank DESC, h.media_id DESC, h.chunk_number
+	`, positive, mediaIDs, f.From, f.To, f.Limit, f.Offset, searchHeadlineOpts, excluded)
+	if err != nil {
+		return nil, 0, fmt.Errorf("search transcripts: %w", err)
+	}
+	defer rows.Close()
+
+	total := 0
+	out := []models.SearchHit{}
+	for rows.Next() {
+		var h models.SearchHit
+		if err := rows.Scan(
+			&h.MediaID,
+			&h.ChunkNumber,
+			&h.OffsetMs,
+			&h.Rank,
+			&total,
+			&h.Snippet,
+			&h.SourceURL,
+			&h.CreatedAt,
+			&h.Title,
+			&h.Uploader,
+			&h.DurationSec,
+			&h.IsLive,
+			&h.LiveStatus,
+			&h.ThumbnailURL,
+			&h.PublishedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		h.Snippet = highlightHTML(h.Snippet)
+		out = append(out, h)
+	}
+	return out, total, rows.Err()
+}
+
+// splitExclusions — websearch-запрос без исключений и сами исключения,
+// склеенные через or: `бюджет -собянин -"мэрия москвы"` →
+// `бюджет`, `собянин or "мэрия москвы"`
+func splitExclusions(query string) (string, string) {
+	var pos, neg []string
+
+	rs := []rune(query)
+	for i := 0; i < len(rs); {
+		if unicode.IsSpace(rs[i]) {
+			i++
+			continue
+		}
+
+		exclude := rs[i] == '-' && i+1 < len(rs) && !unicode.IsSpace(rs[i+1])
+		if exclude {
+			i++
+		}
+
+		start := i
+		if rs[i] == '"' {
+			// фраза — до закрывающей кавычки (или до конца строки)
+			i++
+			for i < len(rs) && rs[i] != '"' {
+				i++
+			}
+			if i < len(rs) {
+				i++
+			}
+		} else {
+			for i < len(rs) && !unicode.IsSpace(rs[i]) {
+				i++
+			}
+		}
+
+		tok := string(rs[start:i])
+		if exclude {
+			neg = append(neg, tok)
+		} else {
+			pos = append(pos, tok)
+		}
+	}
+
+	return strings.Join(pos, " "), strings.Join(neg, " or ")
+}
+
+func highlightHTML(s string) string {
+	return strings.NewReplacer(
+		searchMarkStart, "<mark>",
+		searchMarkStop, "</mark>",
+	).Replace(html.EscapeString(s))
+}
@@ -0,0 +1,74 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+func TestSplitExclusions(t *testing.T) {
+	tests := []struct {
+		query, pos, neg string
+	}{
+		{`бюджет`, `бюджет`, ``},
+		{`бюджет -собянин`, `бюджет`, `собянин`},
+		{`"бюджет города" -собянин -"мэрия москвы"`, `"бюджет города"`, `собянин or "мэрия москвы"`},
+		{`москва - мэрия`, `москва - мэрия`, ``},
+		{`бюджет or налоги -мэр`, `бюджет or налоги`, `мэр`},
+	}
+
+	for _, tt := range tests {
+		pos, neg := splitExclusions(tt.query)
+		if pos != tt.pos || neg != tt.neg {
+			t.Errorf("splitExclusions(%q) = %q, %q; want %q, %q", tt.query, pos, neg, tt.pos, tt.neg)
+		}
+	}
+}
+
+// исключение действует в обеих конфигурациях: «-собянин» отсекает «Собянина»,
+// хотя english-половина text_tsv хранит слово без стемминга
+func TestSearchExclusion(t *testing.T) {
+	pool := testPool(t)
+	repo := NewPostgresMediaRepo(pool)
+	search := NewPostgresSearchRepo(pool)
+	media := testMedia(t, pool)
+	ctx := context.Background()
+
+	texts := []string{
+		"Бюджет города на следующий год утвердили депутаты",
+		"Проект бюджета представил Сергей Собянин",
+		"Бюджет обсуждали в кабинете Собянина",
+	}
+	for i, text := range texts {
+		if err := repo.InsertChunk(ctx, &models.MediaChunk{MediaID: media.ID, ChunkNumber: i + 1, Text: text}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := pool.Exec(ctx, `UPDATE media_chunk SET status = 'done' WHERE media_id = $1`, media.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	find := func(q string) []int {
+		t.Helper()
+		hits, _, err := search.Search(ctx, models.SearchFilter{Query: q, MediaIDs: []int{media.ID}, Limit: 10})
+		if err != nil {
+			t.Fatalf("search %q: %v", q, err)
+		}
+		var chunks []int
+		for _, h := range hits {
+			chunks = append(chunks, h.ChunkNumber)
+		}
+		return chunks
+	}
+
+	if got := find("бюджет"); len(got) != 3 {
+		t.Fatalf("бюджет: chunks %v, want all 3", got)
+	}
+	if got := find("бюджет -собянин"); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("бюджет -собянин: chunks %v, want [1]", got)
+	}
+	if got := find(`бюджет -"сергей собянин"`); len(got) != 2 {
+		t.Fatalf(`бюджет -"сергей собянин": chunks %v, want 2`, got)
+	}
+}
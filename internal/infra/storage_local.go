@@ -0,0 +1,104 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+// LocalStorage — архив на диске; ссылки вида file:///abs/path
+type LocalStorage struct {
+	dir string
+}
+
+func NewLocalStorage(dir string) (ports.ObjectStorage, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(abs, 0755); err != nil {
+		return nil, fmt.Errorf("storage dir: %w", err)
+	}
+	return &LocalStorage{dir: abs}, nil
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("storage put: %w", err)
+	}
+
+	// через tmp + rename: читатель не увидит недописанный файл
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".put-*")
+	if err != nil {
+		return "", fmt.Errorf("storage put: %w", err)
+	}
+
+	_, err = io.Copy(tmp, r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("storage put: %w", err)
+	}
+
+	return "file://" + path, nil
+}
+
+func (s *LocalStorage) Get(ctx context.Context, url string) (io.ReadCloser, error) {
+	path, err := s.fromURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("storage get: %w", err)
+	}
+	return f, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, url string) error {
+	path, err := s.fromURL(url)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("storage delete: %w", err)
+	}
+	return nil
+}
+
+// path — ключ внутри dir; "../" за его пределы не выпускаем
+func (s *LocalStorage) path(key string) (string, error) {
+	p := filepath.Join(s.dir, filepath.FromSlash(key))
+	if !strings.HasPrefix(p, s.dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return p, nil
+}
+
+func (s *LocalStorage) fromURL(url string) (string, error) {
+	p, ok := strings.CutPrefix(url, "file://")
+	if !ok {
+		return "", fmt.Errorf("not a local storage url: %q", url)
+	}
+
+	p = filepath.Clean(p)
+	if !strings.HasPrefix(p, s.dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("url outside of storage: %q", url)
+	}
+	return p, nil
+}
@@ -0,0 +1,289 @@
+package infra
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/ports"
+)
+
+// S3Config — любой S3-совместимый сервис (AWS, MinIO, Yandex Object Storage)
+type S3Config struct {
+	Endpoint  string // https://storage.yandexcloud.net, http://minio:9000
+	Region    string // для MinIO — любой, по умолчанию us-east-1
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool // endpoint/bucket/key вместо bucket.endpoint/key (MinIO)
+}
+
+// S3Storage — без SDK: PUT / GET / DELETE с подписью AWS SigV4.
+// Тело не хэшируем (UNSIGNED-PAYLOAD) — большие файлы идут потоком
+type S3Storage struct {
+	cfg    S3Config
+	base   *url.URL
+	client *http.Client
+}
+
+func NewS3Storage(cfg S3Config) (ports.ObjectStorage, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("s3 storage: endpoint, bucket and keys are required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	base, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("s3 storage: invalid endpoint %q", cfg.Endpoint)
+	}
+
+	return &S3Storage{
+		cfg:    cfg,
+		base:   base,
+		client: &http.Client{},
+	}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	if size < 0 {
+		return "", fmt.Errorf("s3 put: size is required")
+	}
+
+	req, err := s.request(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if err := s.do(req, nil); err != nil {
+		return "", fmt.Errorf("s3 put %s: %w", key, err)
+	}
+	return "s3://" + s.cfg.Bucket + "/" + key, nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, ref string) (io.ReadCloser, error) {
+	key, err := s.key(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.ReadCloser
+	if err := s.do(req, &body); err != nil {
+		return nil, fmt.Errorf("s3 get %s: %w", key, err)
+	}
+	return body, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, ref string) error {
+	key, err := s.key(ref)
+	if err != nil {
+		return err
+	}
+
+	req, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := s.do(req, nil); err != nil {
+		return fmt.Errorf("s3 delete %s: %w", key, err)
+	}
+	return nil
+}
+
+// key — s3://bucket/key → key (только наш bucket)
+func (s *S3Storage) key(ref string) (string, error) {
+	key, ok := strings.CutPrefix(ref, "s3://"+s.cfg.Bucket+"/")
+	if !ok || key == "" {
+		return "", fmt.Errorf("not a storage url of bucket %s: %q", s.cfg.Bucket, ref)
+	}
+	return key, nil
+}
+
+func (s *S3Storage) request(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	u := *s.base
+	if s.cfg.PathStyle {
+		u.Path = u.Path + "/" + s.cfg.Bucket + "/" + key
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path = u.Path + "/" + key
+	}
+	u.RawPath = s3EscapePath(u.Path)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, time.Now().UTC())
+	return req, nil
+}
+
+// do — 2xx или ошибка с началом тела ответа S3; out != nil → тело отдаём вызывающему
+func (s *S3Storage) do(req *http.Request, out *io.ReadCloser) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+
+	if out != nil {
+		*out = resp.Body
+		return nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return nil
+}
+
+// ========================================================================
+// SigV4
+// ========================================================================
+const s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+func (s *S3Storage) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": s3UnsignedPayload,
+		"x-amz-date":           amzDate,
+	}
+	if ct := req.Header.Get("Content-Type"); ct != "" {
+		headers["content-type"] = ct
+	}
+
+	req.Header.Set("Authorization", s.authorization(
+		req.Method, req.URL.EscapedPath(), req.URL.Query(), headers, s3UnsignedPayload, now,
+	))
+}
+
+// authorization — заголовок Authorization по SigV4 для S3;
+// headers — подписываемые, имена в нижнем регистре
+func (s *S3Storage) authorization(
+	method, path string,
+	query url.Values,
+	headers map[string]string,
+	payloadHash string,
+	now time.Time,
+) string {
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonHeaders strings.Builder
+	for _, k := range names {
+		canonHeaders.WriteString(k + ":" + strings.TrimSpace(headers[k]) + "\n")
+	}
+	signed := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{
+		method,
+		path,
+		s3CanonicalQuery(query),
+		canonHeaders.String(),
+		signed,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + s.cfg.Region + "/s3/aws4_request"
+	toSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonical)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), day)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey, scope, signed, hex.EncodeToString(hmacSHA256(key, toSign)),
+	)
+}
+
+// s3CanonicalQuery — ключи по порядку, key=value (пустое значение → "key=")
+func s3CanonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		vals := append([]string(nil), q[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			parts = append(parts, s3Escape(k)+"="+s3Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3EscapePath — URI-encode каждого сегмента, как требует SigV4 для S3
+func s3EscapePath(p string) string {
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		parts[i] = s3Escape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
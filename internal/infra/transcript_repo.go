@@ -0,0 +1,77 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTranscriptRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresTranscriptRepo(pool *pgxpool.Pool) ports.TranscriptRepository {
+	return &PostgresTranscriptRepo{pool: pool}
+}
+
+func (r *PostgresTranscriptRepo) InsertTranscript(ctx context.Context, t *models.Transcript) (*models.Transcript, error) {
+	query := `
+		INSERT INTO media_transcript (media_id, kind, status, text, chunk_from, chunk_to)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+	row := r.pool.QueryRow(ctx, query, t.MediaID, t.Kind, t.Status, t.Text, t.ChunkFrom, t.ChunkTo)
+	if err := row.Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("insert transcript: %w", err)
+	}
+	return t, nil
+}
+
+func (r *PostgresTranscriptRepo) UpdateTranscript(ctx context.Context, t *models.Transcript) error {
+	query := `
+		UPDATE media_transcript
+		SET status = $1, text = $2, chunk_from = $3, chunk_to = $4, error = $5, updated_at = now()
+		WHERE id = $6
+		RETURNING updated_at
+	`
+	row := r.pool.QueryRow(ctx, query, t.Status, t.Text, t.ChunkFrom, t.ChunkTo, t.Error, t.ID)
+	if err := row.Scan(&t.UpdatedAt); err != nil {
+		return fmt.Errorf("update transcript: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresTranscriptRepo) GetLatestTranscript(ctx context.Context, mediaID int, kind string) (*models.Transcript, error) {
+	query := `
+		SELECT id, media_id, kind, status, text, chunk_from, chunk_to, error, created_at, updated_at
+		FROM media_transcript
+		WHERE media_id = $1 AND kind = $2
+		ORDER BY id DESC
+		LIMIT 1
+	`
+
+	var t models.Transcript
+	err := r.pool.QueryRow(ctx, query, mediaID, kind).Scan(
+		&t.ID,
+		&t.MediaID,
+		&t.Kind,
+		&t.Status,
+		&t.Text,
+		&t.ChunkFrom,
+		&t.ChunkTo,
+		&t.Error,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get latest transcript: %w", err)
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,101 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresUsageRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresUsageRepo(pool *pgxpool.Pool) ports.UsageRepository {
+	return &PostgresUsageRepo{pool: pool}
+}
+
+func (r *PostgresUsageRepo) InsertUsage(ctx context.Context, rec *models.UsageRecord) error {
+	query := `
+		INSERT INTO usage_ledger (
+			media_id, chunk_number, user_name,
+			provider, operation, model,
+			units, prompt_tokens, completion_tokens,
+			cost, currency
+		)
+		VALUES (NULLIF($1, 0), NULLIF($2, 0), $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at
+	`
+	row := r.pool.QueryRow(ctx, query,
+		rec.MediaID, rec.ChunkNumber, rec.User,
+		rec.Provider, rec.Operation, rec.Model,
+		rec.Units, rec.PromptTokens, rec.CompletionTokens,
+		rec.Cost, rec.Currency,
+	)
+	if err := row.Scan(&rec.ID, &rec.CreatedAt); err != nil {
+		return fmt.Errorf("insert usage: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresUsageRepo) CostByMedia(ctx context.Context, from, to time.Time) ([]models.CostSummary, error) {
+	return r.summary(ctx, `COALESCE(media_id::text, '')`, from, to)
+}
+
+func (r *PostgresUsageRepo) CostByDay(ctx context.Context, from, to time.Time) ([]models.CostSummary, error) {
+	return r.summary(ctx, `to_char(date_trunc('day', created_at), 'YYYY-MM-DD')`, from, to)
+}
+
+func (r *PostgresUsageRepo) CostByUser(ctx context.Context, from, to time.Time) ([]models.CostSummary, error) {
+	return r.summary(ctx, `user_name`, from, to)
+}
+
+// keyExpr — только константы из методов выше, не пользовательский ввод
+func (r *PostgresUsageRepo) summary(
+	ctx context.Context,
+	keyExpr string,
+	from, to time.Time,
+) ([]models.CostSummary, error) {
+
+	query := fmt.Sprintf(`
+		SELECT %s AS key,
+		       currency,
+		       COUNT(*),
+		       COALESCE(SUM(units), 0),
+		       COALESCE(SUM(prompt_tokens), 0),
+		       COALESCE(SUM(completion_tokens), 0),
+		       COALESCE(SUM(cost), 0)::float8
+		FROM usage_ledger
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY key, currency
+		ORDER BY key ASC, currency ASC
+	`, keyExpr)
+
+	rows, err := r.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("usage summary: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.CostSummary{}
+	for rows.Next() {
+		var s models.CostSummary
+		if err := rows.Scan(
+			&s.Key,
+			&s.Currency,
+			&s.Calls,
+			&s.Units,
+			&s.PromptTokens,
+			&s.CompletionTokens,
+			&s.Cost,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+
+	return out, rows.Err()
+}
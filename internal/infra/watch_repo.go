@@ -0,0 +1,126 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+	"github.com/Vovarama1992/journalist/internal/ports"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWatchRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresWatchRepo(pool *pgxpool.Pool) ports.WatchRepository {
+	return &PostgresWatchRepo{pool: pool}
+}
+
+const watchColumns = `
+	id, url, room_id, user_name, enabled, interval_sec, live_id, media_id,
+	last_checked_at, last_error, created_at
+`
+
+func scanWatch(row pgx.Row, w *models.WatchSubscription) error {
+	return row.Scan(
+		&w.ID,
+		&w.URL,
+		&w.RoomID,
+		&w.User,
+		&w.Enabled,
+		&w.IntervalSec,
+		&w.LiveID,
+		&w.MediaID,
+		&w.LastCheckedAt,
+		&w.LastError,
+		&w.CreatedAt,
+	)
+}
+
+func (r *PostgresWatchRepo) InsertWatch(ctx context.Context, w *models.WatchSubscription) error {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO watch_subscription (url, room_id, user_name, enabled, interval_sec)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`, w.URL, w.RoomID, w.User, w.Enabled, w.IntervalSec,
+	).Scan(&w.ID, &w.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert watch: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresWatchRepo) UpdateWatch(ctx context.Context, w *models.WatchSubscription) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE watch_subscription
+		SET url = $1, room_id = $2, user_name = $3, enabled = $4, interval_sec = $5
+		WHERE id = $6
+	`, w.URL, w.RoomID, w.User, w.Enabled, w.IntervalSec, w.ID)
+	if err != nil {
+		return fmt.Errorf("update watch: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresWatchRepo) DeleteWatch(ctx context.Context, id int) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM watch_subscription WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete watch: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresWatchRepo) GetWatch(ctx context.Context, id int) (*models.WatchSubscription, error) {
+	var w models.WatchSubscription
+	err := scanWatch(r.pool.QueryRow(ctx, `
+		SELECT `+watchColumns+`
+		FROM watch_subscription
+		WHERE id = $1
+	`, id), &w)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get watch: %w", err)
+	}
+	return &w, nil
+}
+
+func (r *PostgresWatchRepo) ListWatches(ctx context.Context) ([]models.WatchSubscription, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+watchColumns+`
+		FROM watch_subscription
+		ORDER BY id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list watches: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.WatchSubscription{}
+	for rows.Next() {
+		var w models.WatchSubscription
+		if err := scanWatch(rows, &w); err != nil {
+			return nil, err
+		}
+		out = append(out, w)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresWatchRepo) SetWatchState(ctx context.Context, id int, liveID string, mediaID int, lastError string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE watch_subscription
+		SET live_id = $1,
+		    media_id = COALESCE(NULLIF($2, 0), media_id),
+		    last_error = $3,
+		    last_checked_at = now()
+		WHERE id = $4
+	`, liveID, mediaID, lastError, id)
+	if err != nil {
+		return fmt.Errorf("set watch state: %w", err)
+	}
+	return nil
+}
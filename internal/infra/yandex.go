@@ -4,27 +4,38 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"os"
+	"strconv"
 
+	"github.com/Vovarama1992/journalist/internal/models"
 	"github.com/Vovarama1992/journalist/internal/ports"
 )
 
+// Yandex SpeechKit тарифицирует распознавание единицами по 15 секунд
+const yandexUnitSeconds = 15
+
 type YandexSTTService struct {
-	apiKey string
-	client *http.Client
+	apiKey    string
+	client    *http.Client
+	usage     ports.UsageRecorder
+	unitPrice float64 // RUB за единицу
 }
 
-func NewYandexSTTService() ports.STTService {
+func NewYandexSTTService(usage ports.UsageRecorder) ports.STTService {
 	key := os.Getenv("YANDEX_SPEECHKIT_API_KEY")
 	if key == "" {
 		panic("YANDEX_SPEECHKIT_API_KEY not set")
 	}
 	return &YandexSTTService{
-		apiKey: key,
-		client: http.DefaultClient,
+		apiKey:    key,
+		client:    http.DefaultClient,
+		usage:     usage,
+		unitPrice: envFloat("YANDEX_STT_UNIT_PRICE", 0.16),
 	}
 }
 
@@ -33,10 +44,15 @@ type yandexResponse struct {
 	Error  string `json:"error_message"`
 }
 
-func (s *YandexSTTService) Recognize(ctx context.Context, pcm []byte) (string, []byte, error) {
+// opts.Hints не используются: v1 sync API не принимает подсказки фраз
+func (s *YandexSTTService) Recognize(ctx context.Context, pcm []byte, opts ports.RecognizeOptions) (string, []byte, error) {
+	lang := opts.Language
+	if lang == "" {
+		lang = "ru-RU"
+	}
 
 	url := "https://stt.api.cloud.yandex.net/speech/v1/stt:recognize" +
-		"?lang=ru-RU&format=lpcm&sampleRateHertz=16000"
+		"?lang=" + lang + "&format=lpcm&sampleRateHertz=16000"
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(pcm))
 	if err != nil {
@@ -64,8 +80,54 @@ func (s *YandexSTTService) Recognize(ctx context.Context, pcm []byte) (string, [
 
 	// ошибка Яндекса
 	if parsed.Error != "" {
-		return "", rawResp, fmt.Errorf(parsed.Error)
+		return "", rawResp, errors.New(parsed.Error)
 	}
 
+	s.recordUsage(ctx, len(pcm))
+
 	return parsed.Result, rawResp, nil
 }
+
+// recordUsage — успешный вызов оплачивается округлением вверх до 15 сек
+func (s *YandexSTTService) recordUsage(ctx context.Context, audioBytes int) {
+	if s.usage == nil {
+		return
+	}
+
+	seconds := float64(audioBytes) / 2 / 16000
+	units := int(seconds) / yandexUnitSeconds
+	if float64(units*yandexUnitSeconds) < seconds {
+		units++
+	}
+
+	scope := ports.UsageScopeFrom(ctx)
+	rec := &models.UsageRecord{
+		MediaID:     scope.MediaID,
+		ChunkNumber: scope.ChunkNumber,
+		User:        scope.User,
+		Provider:    "yandex",
+		Operation:   "stt",
+		Model:       "speechkit-v1",
+		Units:       units,
+		Cost:        float64(units) * s.unitPrice,
+		Currency:    "RUB",
+	}
+
+	if err := s.usage.InsertUsage(context.WithoutCancel(ctx), rec); err != nil {
+		log.Printf("[USAGE][ERR] yandex media=%d chunk=%d err=%v",
+			scope.MediaID, scope.ChunkNumber, err)
+	}
+}
+
+func envFloat(name string, def float64) float64 {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		log.Printf("WARN: %s=%q is not a number, using %v", name, v, def)
+		return def
+	}
+	return f
+}
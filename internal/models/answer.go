@@ -0,0 +1,17 @@
+package models
+
+type AnswerCitation struct {
+	ChunkNumber int    `json:"chunk"`
+	Text        string `json:"text"`
+	OffsetMs    int64  `json:"offsetMs"`
+}
+
+// Answer — ответ LLM по транскрипту со ссылками на чанки
+type Answer struct {
+	MediaID   int              `json:"mediaID"`
+	Question  string           `json:"question"`
+	Answer    string           `json:"answer"`
+	Citations []AnswerCitation `json:"citations"`
+	// сколько готовых чанков было на момент вопроса (для live)
+	ChunksSeen int `json:"chunksSeen"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// ArticleParams — с чем генерировался черновик (хранится для регенерации)
+type ArticleParams struct {
+	Tone      string `json:"tone,omitempty"`      // "нейтральный", "информационный", ...
+	Length    string `json:"length,omitempty"`    // "short" | "medium" | "long"
+	Focus     string `json:"focus,omitempty"`     // на чём сделать акцент
+	Language  string `json:"language,omitempty"`  // язык текста, по умолчанию русский
+	Headlines int    `json:"headlines,omitempty"` // сколько вариантов заголовка
+}
+
+type ArticleQuote struct {
+	Speaker string `json:"speaker"`
+	Text    string `json:"text"`
+}
+
+type ArticleDraft struct {
+	ID              int            `db:"id" json:"id"`
+	MediaID         int            `db:"media_id" json:"mediaID"`
+	ChunkFrom       int            `db:"chunk_from" json:"chunkFrom"`
+	ChunkTo         int            `db:"chunk_to" json:"chunkTo"`
+	Params          ArticleParams  `db:"params" json:"params"`
+	Headlines       []string       `db:"headlines" json:"headlines"`
+	Lead            string         `db:"lead" json:"lead"`
+	Body            string         `db:"body" json:"body"`
+	Quotes          []ArticleQuote `db:"quotes" json:"quotes"`
+	Edited          bool           `db:"edited" json:"edited"`
+	ParentID        *int           `db:"parent_id" json:"parentID,omitempty"`     // версия правленного черновика
+	SourceTruncated bool           `db:"source_truncated" json:"sourceTruncated"` // писалось только по началу расшифровки
+	CreatedAt       time.Time      `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time      `db:"updated_at" json:"updatedAt"`
+}
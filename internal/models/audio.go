@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// AudioSegment — кусок непрерывной записи эфира (MP3, CBR)
+type AudioSegment struct {
+	ID         int       `db:"id" json:"id"`
+	MediaID    int       `db:"media_id" json:"mediaID"`
+	Seq        int       `db:"seq" json:"seq"`
+	OffsetMs   int64     `db:"offset_ms" json:"offsetMs"`
+	DurationMs int       `db:"duration_ms" json:"durationMs"`
+	Bytes      int64     `db:"bytes" json:"bytes"`
+	StorageURL string    `db:"storage_url" json:"-"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+}
@@ -0,0 +1,37 @@
+package models
+
+// каналы, которые можно взять из стерео-дорожки вместо даунмикса
+const (
+	ChannelLeft  = "left"
+	ChannelRight = "right"
+)
+
+// AudioTrack — аудиодорожка источника (ffprobe)
+type AudioTrack struct {
+	Index      int    `json:"index"` // номер среди аудиодорожек: 0:a:N
+	Codec      string `json:"codec"`
+	Language   string `json:"language,omitempty"` // тег дорожки, обычно ISO 639-2 (rus, eng)
+	Title      string `json:"title,omitempty"`
+	Channels   int    `json:"channels"`
+	Layout     string `json:"layout,omitempty"`
+	SampleRate int    `json:"sampleRate"`
+	Default    bool   `json:"default"`
+}
+
+// AudioSelection — какую дорожку / канал слушать; пусто → дорожка
+// по умолчанию, даунмикс в моно
+type AudioSelection struct {
+	Track    *int   `json:"track,omitempty"`
+	Language string `json:"language,omitempty"` // ищется среди тегов дорожек: en, eng
+	Channel  string `json:"channel,omitempty"`  // left | right
+}
+
+func (s AudioSelection) IsZero() bool {
+	return s.Track == nil && s.Language == "" && s.Channel == ""
+}
+
+// TrackReport — что нашлось в источнике и что выбрано
+type TrackReport struct {
+	Tracks   []AudioTrack   `json:"tracks"`
+	Selected AudioSelection `json:"selected"`
+}
@@ -0,0 +1,8 @@
+package models
+
+type CacheStat struct {
+	Kind   string `json:"kind"`
+	Hits   int64  `json:"hits"`
+	Misses int64  `json:"misses"`
+	Errors int64  `json:"errors"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+const (
+	CookieJarUnchecked = "unchecked"
+	CookieJarOK        = "ok"
+	CookieJarFailed    = "failed"
+)
+
+// CookieJar — cookies.txt для yt-dlp; содержимое наружу не отдаём
+type CookieJar struct {
+	ID        int        `db:"id" json:"id"`
+	Domain    string     `db:"domain" json:"domain"`
+	Name      string     `db:"name" json:"name"`
+	Content   string     `db:"content" json:"-"`
+	Active    bool       `db:"active" json:"active"`
+	Status    string     `db:"status" json:"status"`
+	LastError string     `db:"last_error" json:"lastError"`
+	CheckedAt *time.Time `db:"checked_at" json:"checkedAt"`
+	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
+}
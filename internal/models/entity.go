@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	EntityPerson       = "person"
+	EntityOrganization = "organization"
+	EntityPlace        = "place"
+	EntityNumber       = "number"
+	EntityDate         = "date"
+	EntityName         = "name" // имя собственное без типа (rule-based)
+)
+
+type Entity struct {
+	ID          int       `db:"id" json:"id"`
+	MediaID     int       `db:"media_id" json:"mediaID"`
+	ChunkID     int       `db:"chunk_id" json:"chunkID"`
+	ChunkNumber int       `db:"chunk_number" json:"chunk"`
+	Kind        string    `db:"kind" json:"kind"`
+	Value       string    `db:"value" json:"value"`
+	Normalized  string    `db:"normalized" json:"normalized"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
+
+// EntityMention — упоминание в выдаче поиска по индексу
+type EntityMention struct {
+	MediaID     int       `json:"mediaID"`
+	SourceURL   string    `json:"sourceURL"`
+	MediaAt     time.Time `json:"mediaCreatedAt"`
+	ChunkNumber int       `json:"chunk"`
+	Kind        string    `json:"kind"`
+	Value       string    `json:"value"`
+	Text        string    `json:"text"`
+}
+
+// NormalizeEntity — ключ поиска: нижний регистр, ё→е, одиночные пробелы
+func NormalizeEntity(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.ReplaceAll(s, "ё", "е")
+	return strings.Join(strings.Fields(s), " ")
+}
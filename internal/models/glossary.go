@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type GlossaryTerm struct {
+	ID        int       `db:"id" json:"id"`
+	MediaID   *int      `db:"media_id" json:"mediaID"` // nil → для всех media
+	Term      string    `db:"term" json:"term"`
+	Spelling  string    `db:"spelling" json:"spelling"`
+	Aliases   []string  `db:"aliases" json:"aliases"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
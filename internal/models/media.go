@@ -8,4 +8,29 @@ type Media struct {
 	StorageURL *string   `db:"storage_url"` // nullable, URL в реальном хранилище
 	Type       string    `db:"media_type"`  // "audio" или "video"
 	CreatedAt  time.Time `db:"created_at"`
+	// пресет фильтров перед STT; пусто → по умолчанию сервера
+	AudioFilter string `db:"audio_filter"`
+	// эфир кончился / запись обработана целиком (models.MediaEnd*)
+	FinishedAt *time.Time `db:"finished_at"`
+	EndReason  string     `db:"end_reason"`
+
+	MediaMeta
+}
+
+// почему media завершена
+const (
+	MediaEndCompleted   = "completed"    // запись обработана до конца
+	MediaEndStreamEnded = "stream_ended" // источник сообщил, что эфир окончен
+	MediaEndNoSignal    = "no_signal"    // S1/S2 подряд не получают звук
+)
+
+// MediaMeta — метаданные источника (yt-dlp -J)
+type MediaMeta struct {
+	Title        string     `db:"title" json:"title"`
+	Uploader     string     `db:"uploader" json:"uploader"`
+	DurationSec  int        `db:"duration_sec" json:"durationSec"`
+	IsLive       bool       `db:"is_live" json:"isLive"`
+	LiveStatus   string     `db:"live_status" json:"liveStatus"`
+	ThumbnailURL string     `db:"thumbnail_url" json:"thumbnailURL"`
+	PublishedAt  *time.Time `db:"published_at" json:"publishedAt"`
 }
@@ -1,10 +1,22 @@
 package models
 
+import "time"
+
 type MediaChunk struct {
-	ID          int    `db:"id"`
-	MediaID     int    `db:"media_id"`
-	ChunkNumber int    `db:"chunk_number"`
-	Text        string `db:"text"`
-	FilePath    string `db:"file_path"`
-	Status      string `db:"status"`
+	ID          int       `db:"id"`
+	MediaID     int       `db:"media_id"`
+	ChunkNumber int       `db:"chunk_number"`
+	Text        string    `db:"text"`
+	RawText     string    `db:"raw_text"` // как распознал STT, до S5
+	FilePath    string    `db:"file_path"`
+	Status      string    `db:"status"`
+	CapturedAt  time.Time `db:"captured_at"`
+	OffsetMs    int64     `db:"offset_ms"` // от начала media
+	DurationMs  int       `db:"duration_ms"`
+	StorageURL  string    `db:"storage_url"` // WAV в архиве (ports.ObjectStorage)
+
+	// предобработка: пресет и RMS окна до / после фильтров, dBFS
+	AudioFilter string   `db:"audio_filter"`
+	RMSBeforeDB *float64 `db:"rms_before_db"`
+	RMSAfterDB  *float64 `db:"rms_after_db"`
 }
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// типы уведомлений
+const (
+	NoticeLiveStarted = "live_started"
+	NoticeLiveEnded   = "live_ended"
+)
+
+// Notification — событие для людей: в room и во внешний webhook
+type Notification struct {
+	Kind    string    `json:"kind"`
+	RoomID  string    `json:"roomID"`
+	MediaID int       `json:"mediaID,omitempty"`
+	Title   string    `json:"title,omitempty"`
+	URL     string    `json:"url,omitempty"`
+	Text    string    `json:"text"`
+	At      time.Time `json:"at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Quote struct {
+	ID          int       `db:"id" json:"id"`
+	MediaID     int       `db:"media_id" json:"mediaID"`
+	ChunkNumber int       `db:"chunk_number" json:"chunk"`
+	Speaker     string    `db:"speaker" json:"speaker"`
+	Text        string    `db:"text" json:"text"`
+	Verbatim    string    `db:"verbatim" json:"verbatim"`
+	OffsetMs    int64     `db:"offset_ms" json:"offsetMs"`
+	DurationMs  int       `db:"duration_ms" json:"durationMs"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// статусы запланированной сессии
+const (
+	SchedulePlanned  = "planned"
+	ScheduleWaiting  = "waiting" // время пришло, поток ещё не поднят — повторяем
+	ScheduleRunning  = "running"
+	ScheduleDone     = "done"
+	ScheduleFailed   = "failed"
+	ScheduleCanceled = "canceled"
+)
+
+// ScheduledSession — сессия, которую сервер сам запустит и остановит
+type ScheduledSession struct {
+	ID             int        `db:"id" json:"id"`
+	URL            string     `db:"url" json:"url"`
+	RoomID         string     `db:"room_id" json:"roomID"`
+	User           string     `db:"user_name" json:"user"`
+	Language       string     `db:"language" json:"language"`
+	Prompt         string     `db:"prompt" json:"prompt"`
+	StartAt        time.Time  `db:"start_at" json:"startAt"`
+	MaxDurationSec int        `db:"max_duration_sec" json:"maxDurationSec"`
+	Status         string     `db:"status" json:"status"`
+	Attempts       int        `db:"attempts" json:"attempts"`
+	MediaID        *int       `db:"media_id" json:"mediaID"`
+	LastError      string     `db:"last_error" json:"lastError"`
+	StartedAt      *time.Time `db:"started_at" json:"startedAt"`
+	FinishedAt     *time.Time `db:"finished_at" json:"finishedAt"`
+	CreatedAt      time.Time  `db:"created_at" json:"createdAt"`
+}
+
+// EndAt — позже этого момента сессия не идёт и не стартует
+func (s *ScheduledSession) EndAt() time.Time {
+	return s.StartAt.Add(time.Duration(s.MaxDurationSec) * time.Second)
+}
+
+// Active — ещё может стартовать или уже идёт
+func (s *ScheduledSession) Active() bool {
+	switch s.Status {
+	case SchedulePlanned, ScheduleWaiting, ScheduleRunning:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// SearchFilter — GET /api/search: запрос в синтаксисе websearch
+// ("фраза в кавычках", or, -исключить) и необязательные фильтры
+type SearchFilter struct {
+	Query    string
+	MediaIDs []int
+	From     *time.Time // дата трансляции: published_at, иначе created_at
+	To       *time.Time // не включительно
+	Limit    int
+	Offset   int
+}
+
+// SearchHit — чанк в выдаче поиска по транскриптам
+type SearchHit struct {
+	MediaID     int     `json:"mediaID"`
+	ChunkNumber int     `json:"chunk"`
+	OffsetMs    int64   `json:"offsetMs"`
+	Rank        float64 `json:"rank"`
+	// Snippet — HTML: текст экранирован, совпадения в <mark>
+	Snippet string `json:"snippet"`
+
+	SourceURL string    `json:"sourceURL"`
+	CreatedAt time.Time `json:"mediaCreatedAt"`
+	MediaMeta
+}
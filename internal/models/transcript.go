@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+const (
+	TranscriptEdited = "edited"
+
+	TranscriptRunning = "running"
+	TranscriptDone    = "done"
+	TranscriptFailed  = "failed"
+)
+
+// Transcript — версия полного текста media (например, после редактуры)
+type Transcript struct {
+	ID        int       `db:"id" json:"id"`
+	MediaID   int       `db:"media_id" json:"mediaID"`
+	Kind      string    `db:"kind" json:"kind"`
+	Status    string    `db:"status" json:"status"`
+	Text      string    `db:"text" json:"text"`
+	ChunkFrom int       `db:"chunk_from" json:"chunkFrom"`
+	ChunkTo   int       `db:"chunk_to" json:"chunkTo"`
+	Error     string    `db:"error" json:"error,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
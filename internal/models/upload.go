@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Upload — загрузка файла (диктофонная запись и т.п.), в т.ч. по частям
+type Upload struct {
+	ID        string    `json:"uploadId"`
+	Filename  string    `json:"filename"`
+	Total     int64     `json:"total"` // 0 → размер заранее неизвестен (один запрос)
+	Received  int64     `json:"received"`
+	RoomID    string    `json:"roomID"`
+	User      string    `json:"user"`
+	MediaID   int       `json:"mediaID,omitempty"` // появляется после проверки файла
+	Started   bool      `json:"started,omitempty"` // обработка запущена
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Complete — все байты приняты (Total == 0: единственная часть уже пришла)
+func (u *Upload) Complete() bool {
+	return u.Received > 0 && (u.Total == 0 || u.Received == u.Total)
+}
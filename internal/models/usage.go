@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// UsageRecord — одна запись в ledger: вызов STT или LLM
+type UsageRecord struct {
+	ID               int       `db:"id"`
+	MediaID          int       `db:"media_id"`     // 0 → NULL
+	ChunkNumber      int       `db:"chunk_number"` // 0 → NULL
+	User             string    `db:"user_name"`
+	Provider         string    `db:"provider"`
+	Operation        string    `db:"operation"`
+	Model            string    `db:"model"`
+	Units            int       `db:"units"`
+	PromptTokens     int       `db:"prompt_tokens"`
+	CompletionTokens int       `db:"completion_tokens"`
+	Cost             float64   `db:"cost"`
+	Currency         string    `db:"currency"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+// CostSummary — агрегат по ключу (media / день / пользователь)
+type CostSummary struct {
+	Key              string  `json:"key"`
+	Currency         string  `json:"currency"`
+	Calls            int     `json:"calls"`
+	Units            int     `json:"units"`
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	Cost             float64 `json:"cost"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// WatchSubscription — канал, за которым следим: эфир начался → сессия в room
+type WatchSubscription struct {
+	ID            int        `db:"id" json:"id"`
+	URL           string     `db:"url" json:"url"`
+	RoomID        string     `db:"room_id" json:"roomID"`
+	User          string     `db:"user_name" json:"user"`
+	Enabled       bool       `db:"enabled" json:"enabled"`
+	IntervalSec   int        `db:"interval_sec" json:"intervalSec"`
+	LiveID        string     `db:"live_id" json:"liveID"`
+	MediaID       *int       `db:"media_id" json:"mediaID"`
+	LastCheckedAt *time.Time `db:"last_checked_at" json:"lastCheckedAt"`
+	LastError     string     `db:"last_error" json:"lastError"`
+	CreatedAt     time.Time  `db:"created_at" json:"createdAt"`
+}
+
+// LiveBroadcast — эфир, найденный на канале
+type LiveBroadcast struct {
+	ID    string `json:"id"`
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
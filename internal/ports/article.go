@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+var (
+	ErrArticleNotFound = errors.New("article not found")
+	ErrArticleNoText   = errors.New("no transcript text in range")
+)
+
+type ArticleRepository interface {
+	InsertArticle(ctx context.Context, a *models.ArticleDraft) (*models.ArticleDraft, error)
+	UpdateArticle(ctx context.Context, a *models.ArticleDraft) error
+	GetArticle(ctx context.Context, id int) (*models.ArticleDraft, error)
+	ListArticles(ctx context.Context, mediaID int) ([]models.ArticleDraft, error)
+}
+
+type ArticleGenerator interface {
+	// chunkFrom/chunkTo = 0 → вся media
+	Generate(ctx context.Context, mediaID, chunkFrom, chunkTo int, params models.ArticleParams) (*models.ArticleDraft, error)
+	// params == nil → с прежними параметрами; правленный руками черновик
+	// не перезаписывается — возвращается новая версия (ParentID = id)
+	Regenerate(ctx context.Context, id int, params *models.ArticleParams) (*models.ArticleDraft, error)
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"io"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+type AudioSegmentRepository interface {
+	InsertAudioSegment(ctx context.Context, seg *models.AudioSegment) error
+	ListAudioSegments(ctx context.Context, mediaID int) ([]models.AudioSegment, error)
+}
+
+type AudioArchive interface {
+	// Open — запись media одним MP3-потоком поверх сегментов (для Range)
+	Open(ctx context.Context, mediaID int) (io.ReadSeekCloser, []models.AudioSegment, error)
+	// ByteAt — позиция в потоке для момента offsetMs от начала media
+	ByteAt(segs []models.AudioSegment, offsetMs int64) int64
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+type ResultCache interface {
+	Get(ctx context.Context, key string) (value string, ok bool, err error)
+	Set(ctx context.Context, key, kind, value string, ttl time.Duration) error
+	// PurgeExpired — удалить просроченные записи, вернуть сколько удалено
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type CacheStats interface {
+	Stats() []models.CacheStat
+}
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+var (
+	ErrCookieJarNotFound = errors.New("cookie jar not found")
+	ErrCookieJarInvalid  = errors.New("invalid cookie jar")
+	ErrCookieJarCheck    = errors.New("cookie jar test resolve failed")
+)
+
+type CookieJarRepository interface {
+	InsertJar(ctx context.Context, jar *models.CookieJar) error
+	GetJar(ctx context.Context, id int) (*models.CookieJar, error)
+	ListJars(ctx context.Context) ([]models.CookieJar, error)
+	SetJarCheck(ctx context.Context, id int, status, lastError string) error
+	// ActivateJar — сделать активным; прежний активный jar домена гасится
+	ActivateJar(ctx context.Context, id int) error
+	DeleteJar(ctx context.Context, id int) error
+}
+
+type CookieJarManager interface {
+	// Upload — проверка test resolve'ом; прошёл → сразу активен (ротация)
+	Upload(ctx context.Context, domain, name, content, testURL string) (*models.CookieJar, error)
+	Activate(ctx context.Context, id int) (*models.CookieJar, error)
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context) ([]models.CookieJar, error)
+}
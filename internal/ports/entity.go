@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+type EntityRepository interface {
+	// ReplaceChunkEntities — сущности чанка целиком (повторная индексация идемпотентна)
+	ReplaceChunkEntities(ctx context.Context, chunk *models.MediaChunk, entities []models.Entity) error
+	ListMediaEntities(ctx context.Context, mediaID int) ([]models.Entity, error)
+	// FindMentions — все чанки, где встречается сущность (по началу normalized)
+	FindMentions(ctx context.Context, query, kind string, limit int) ([]models.EntityMention, error)
+}
+
+type EntityExtractor interface {
+	Extract(ctx context.Context, text string) ([]models.Entity, error)
+}
+
+type EntityIndexer interface {
+	IndexChunk(chunk models.MediaChunk)
+	IndexMedia(ctx context.Context, mediaID int) (int, error)
+}
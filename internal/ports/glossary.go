@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+var ErrGlossaryTermNotFound = errors.New("glossary term not found")
+
+type GlossaryRepository interface {
+	InsertTerm(ctx context.Context, term *models.GlossaryTerm) (*models.GlossaryTerm, error)
+	// UpdateTerm — все поля, включая media_id; term перечитывается из БД
+	UpdateTerm(ctx context.Context, term *models.GlossaryTerm) error
+	DeleteTerm(ctx context.Context, id int) error
+
+	// термины media + общие (media_id IS NULL); mediaID=0 → только общие
+	ListTerms(ctx context.Context, mediaID int) ([]models.GlossaryTerm, error)
+}
@@ -1,11 +1,41 @@
 package ports
 
-import "context"
+import (
+	"context"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+type ChunkOptions struct {
+	// правильные написания имён и терминов — подмешиваются в промпт
+	Glossary []models.GlossaryTerm
+	// дополнительные указания сессии (тема, спикеры, стиль) — в конец system prompt
+	Prompt string
+}
 
 type GPTService interface {
 	ProcessChunk(
 		ctx context.Context,
 		lastChunk string,
 		newChunk string,
+		opts ChunkOptions,
 	) (string, error)
 }
+
+type CompletionRequest struct {
+	Operation string // для ledger: "polish", "article", ...
+	System    string
+	User      string
+	MaxTokens int  // 0 → по умолчанию клиента
+	JSON      bool // ответ строго JSON-объектом
+}
+
+// LLMService — произвольные задачи поверх той же модели, что и S5
+type LLMService interface {
+	Complete(ctx context.Context, req CompletionRequest) (string, error)
+}
+
+type LLMClient interface {
+	GPTService
+	LLMService
+}
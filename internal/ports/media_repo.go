@@ -13,16 +13,31 @@ type MediaRepository interface {
 	UpdateChunkText(ctx context.Context, chunkID int, text string) error
 	GetLastChunkNumber(ctx context.Context, mediaID int) (int, error)
 	GetMediaByID(ctx context.Context, id int) (*models.Media, error)
+	ListMedia(ctx context.Context, limit, offset int) ([]models.Media, error)
+	UpdateMediaMeta(ctx context.Context, mediaID int, meta *models.MediaMeta) error
+	SetMediaStorage(ctx context.Context, mediaID int, url string) error
+	SetChunkStorage(ctx context.Context, mediaID, chunkNumber int, url string) error
+	SetMediaFilter(ctx context.Context, mediaID int, preset string) error
+	FinishMedia(ctx context.Context, mediaID int, reason string) error
+	SetChunkLevels(ctx context.Context, mediaID, chunkNumber int, preset string, before, after float64) error
 	GetMediaHistory(ctx context.Context, mediaID int) (string, error)
 	GetLastChunk(ctx context.Context, mediaID int) (*models.MediaChunk, error)
 	GetLastCompletedChunk(ctx context.Context, mediaID int) (*models.MediaChunk, error)
+	ListCompletedChunks(ctx context.Context, mediaID int) ([]models.MediaChunk, error)
+	GetChunk(ctx context.Context, mediaID, chunkNumber int) (*models.MediaChunk, error)
+	// SearchChunks — готовые чанки media по релевантности к запросу (full-text, любое слово)
+	SearchChunks(ctx context.Context, mediaID int, query string, limit int) ([]models.MediaChunk, error)
 
 	// NEW for overlapped ingest
-	InsertPendingChunk(ctx context.Context, mediaID int, filePath string) (*models.MediaChunk, error)
+	// AllocChunkNumber — следующий номер чанка media; атомарно, номера не повторяются
+	AllocChunkNumber(ctx context.Context, mediaID int) (int, error)
+	// InsertPendingChunk — ChunkNumber == 0 → номер выдаётся при вставке
+	InsertPendingChunk(ctx context.Context, chunk *models.MediaChunk) (*models.MediaChunk, error)
 	CompleteChunk(
 		ctx context.Context,
 		mediaID int,
 		chunkNumber int,
+		raw string,
 		text string,
 	) error
 }
@@ -2,18 +2,84 @@ package ports
 
 import (
 	"context"
+	"errors"
+	"regexp"
 
 	"github.com/Vovarama1992/journalist/internal/models"
 )
 
+// типы событий для комнаты
+const (
+	EventChunk    = "chunk"    // готов текст чанка
+	EventProgress = "progress" // файл / запись: доля обработанного
+	EventNotice   = "notice"   // уведомление: эфир начался / кончился
+	EventTracks   = "tracks"   // аудиодорожки источника и выбранная
+	EventError    = "error"    // сбой источника: Code — SourceCode*, Text — подробности
+
+	EventMediaFinished = "media_finished" // эфир кончился / запись обработана; Text — причина
+)
+
 type ChunkEvent struct {
+	Type        string
 	RoomID      string
 	MediaID     int
 	ChunkNumber int
 	Text        string
+	Progress    float64              // 0..1, только для EventProgress
+	Notice      *models.Notification // только для EventNotice
+	Tracks      *models.TrackReport  // только для EventTracks
+	Code        string               // только для EventError
+}
+
+// SessionOptions — настройки одной сессии (расписание и т.п.);
+// кладутся в context перед Process, как UsageScope
+type SessionOptions struct {
+	Language string // язык речи для STT; пусто → по умолчанию провайдера
+	Prompt   string // доп. указания для S5
+	// дорожка / канал источника; в context live-сессии — уже проверенный выбор
+	Audio models.AudioSelection
+	// Filter — пресет предобработки; сохраняется в media
+	Filter string
+}
+
+var sttLanguageRe = regexp.MustCompile(`^[a-z]{2}-[A-Z]{2}$`)
+
+// ValidLanguage — SessionOptions.Language в виде ru-RU, en-US
+func ValidLanguage(lang string) bool { return sttLanguageRe.MatchString(lang) }
+
+type sessionOptionsKey struct{}
+
+func WithSessionOptions(ctx context.Context, opts SessionOptions) context.Context {
+	return context.WithValue(ctx, sessionOptionsKey{}, opts)
+}
+
+func SessionOptionsFrom(ctx context.Context) SessionOptions {
+	opts, _ := ctx.Value(sessionOptionsKey{}).(SessionOptions)
+	return opts
 }
 
 type MediaProcessor interface {
 	Process(ctx context.Context, url, roomID string, mediaID int) (*models.Media, error)
 	Events() <-chan ChunkEvent
 }
+
+var (
+	// ErrTrackNotFound — в источнике нет запрошенной дорожки / канала
+	ErrTrackNotFound = errors.New("audio track not found")
+	// ErrFilterUnknown — нет такого пресета предобработки
+	ErrFilterUnknown = errors.New("unknown audio filter preset")
+	ErrMediaNotFound = errors.New("media not found")
+	// ErrMediaFinished — эфир media уже кончился, продолжать нечего
+	ErrMediaFinished = errors.New("media already finished")
+)
+
+type TrackProber interface {
+	// ProbeTracks — аудиодорожки источника до старта сессии
+	ProbeTracks(ctx context.Context, url string) ([]models.AudioTrack, error)
+}
+
+type AudioFilterManager interface {
+	FilterPresets() map[string]string
+	// SetFilter — пресет media; идущая сессия применит со следующего окна
+	SetFilter(ctx context.Context, mediaID int, preset string) error
+}
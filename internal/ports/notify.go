@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+type Notifier interface {
+	Notify(ctx context.Context, n models.Notification) error
+}
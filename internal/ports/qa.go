@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+type QuestionAnswerer interface {
+	Ask(ctx context.Context, mediaID int, question string) (*models.Answer, error)
+}
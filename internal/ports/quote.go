@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+var (
+	ErrQuoteNotFound      = errors.New("quote not found")
+	ErrQuoteAudioNotFound = errors.New("chunk audio not found")
+)
+
+type QuoteRepository interface {
+	// ReplaceQuotes — заменить все цитаты media результатом новой выборки
+	ReplaceQuotes(ctx context.Context, mediaID int, quotes []models.Quote) error
+	ListQuotes(ctx context.Context, mediaID int) ([]models.Quote, error)
+	GetQuote(ctx context.Context, id int) (*models.Quote, error)
+}
+
+type QuoteExtractor interface {
+	Extract(ctx context.Context, mediaID int) ([]models.Quote, error)
+	// Clip — WAV-фрагмент аудио чанка под цитату
+	Clip(ctx context.Context, quoteID int) ([]byte, error)
+}
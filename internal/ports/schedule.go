@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+var (
+	ErrScheduleNotFound = errors.New("scheduled session not found")
+	ErrScheduleInvalid  = errors.New("invalid scheduled session")
+	ErrScheduleState    = errors.New("scheduled session already started or finished")
+)
+
+type ScheduleRepository interface {
+	InsertSchedule(ctx context.Context, s *models.ScheduledSession) error
+	UpdateSchedule(ctx context.Context, s *models.ScheduledSession) error
+	GetSchedule(ctx context.Context, id int) (*models.ScheduledSession, error)
+	// ListSchedules — status пусто → все; от новых start_at к старым
+	ListSchedules(ctx context.Context, status string, limit, offset int) ([]models.ScheduledSession, error)
+	// ListActiveSchedules — planned / waiting / running, по start_at
+	ListActiveSchedules(ctx context.Context) ([]models.ScheduledSession, error)
+	// SetScheduleState — status, attempts, media, ошибка и отметки started / finished
+	SetScheduleState(ctx context.Context, s *models.ScheduledSession) error
+}
+
+type ScheduleManager interface {
+	Create(ctx context.Context, s *models.ScheduledSession) error
+	// Update — только пока сессия не стартовала
+	Update(ctx context.Context, s *models.ScheduledSession) error
+	// Cancel — снять с расписания; идущая сессия останавливается
+	Cancel(ctx context.Context, id int) (*models.ScheduledSession, error)
+	Get(ctx context.Context, id int) (*models.ScheduledSession, error)
+	List(ctx context.Context, status string, limit, offset int) ([]models.ScheduledSession, error)
+}
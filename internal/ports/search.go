@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+type TranscriptSearcher interface {
+	// Search — готовые чанки всех media по релевантности; total — всего совпадений
+	Search(ctx context.Context, f models.SearchFilter) (hits []models.SearchHit, total int, err error)
+}
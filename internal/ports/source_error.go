@@ -0,0 +1,64 @@
+package ports
+
+import "errors"
+
+// классы сбоев источника — по stderr yt-dlp / ffmpeg / ffprobe
+var (
+	// ErrSourceUnavailable — удалено, приватное, эфир ещё не начался
+	ErrSourceUnavailable = errors.New("source unavailable")
+	// ErrSourceAuth — нужен вход / cookies: возраст, подписка, проверка на бота
+	ErrSourceAuth = errors.New("source requires auth")
+	// ErrSourceRateLimited — 429 и «попробуйте позже»
+	ErrSourceRateLimited = errors.New("source rate limited")
+	// ErrSourceGeoBlocked — недоступно из страны сервера
+	ErrSourceGeoBlocked = errors.New("source geo-blocked")
+	// ErrSourceNetwork — DNS, таймаут, обрыв соединения, 5xx
+	ErrSourceNetwork = errors.New("source network error")
+	// ErrSourceUnsupported — не тот URL или формат
+	ErrSourceUnsupported = errors.New("source unsupported")
+)
+
+// коды для клиента (WS / HTTP), по одному на класс
+const (
+	SourceCodeUnavailable = "unavailable"
+	SourceCodeAuth        = "auth_required"
+	SourceCodeRateLimited = "rate_limited"
+	SourceCodeGeoBlocked  = "geo_blocked"
+	SourceCodeNetwork     = "network"
+	SourceCodeUnsupported = "unsupported"
+)
+
+var sourceCodes = []struct {
+	kind error
+	code string
+}{
+	{ErrSourceUnavailable, SourceCodeUnavailable},
+	{ErrSourceAuth, SourceCodeAuth},
+	{ErrSourceRateLimited, SourceCodeRateLimited},
+	{ErrSourceGeoBlocked, SourceCodeGeoBlocked},
+	{ErrSourceNetwork, SourceCodeNetwork},
+	{ErrSourceUnsupported, SourceCodeUnsupported},
+}
+
+// SourceError — сбой внешнего инструмента с классом (Kind — один из ErrSource*)
+type SourceError struct {
+	Kind   error
+	Tool   string // yt-dlp, ffmpeg, ffprobe
+	Detail string // строка stderr, по которой определён класс
+}
+
+func (e *SourceError) Error() string {
+	return e.Tool + ": " + e.Kind.Error() + ": " + e.Detail
+}
+
+func (e *SourceError) Unwrap() error { return e.Kind }
+
+// SourceErrorCode — код класса для клиента; "" — ошибка не классифицирована
+func SourceErrorCode(err error) string {
+	for _, c := range sourceCodes {
+		if errors.Is(err, c.kind) {
+			return c.code
+		}
+	}
+	return ""
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+// ObjectStorage — архив аудио (оригиналы, чанки). Ссылка, которую вернул Put,
+// пишется в media / media_chunk и потом отдаётся обратно в Get / Delete
+type ObjectStorage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error)
+	Get(ctx context.Context, url string) (io.ReadCloser, error)
+	Delete(ctx context.Context, url string) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+type TranscriptRepository interface {
+	InsertTranscript(ctx context.Context, t *models.Transcript) (*models.Transcript, error)
+	UpdateTranscript(ctx context.Context, t *models.Transcript) error
+	GetLatestTranscript(ctx context.Context, mediaID int, kind string) (*models.Transcript, error)
+}
+
+type TranscriptPolisher interface {
+	// Enqueue — запустить редактуру полного текста media в фоне
+	Enqueue(mediaID int)
+}
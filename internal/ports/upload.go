@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+var (
+	ErrUploadNotFound = errors.New("upload not found")
+	ErrUploadTooLarge = errors.New("upload too large")
+	ErrUploadFormat   = errors.New("unsupported file format")
+	ErrUploadOffset   = errors.New("upload offset mismatch")
+)
+
+type MediaUploader interface {
+	// Begin — новая загрузка; total = 0 → файл придёт одним Write
+	Begin(ctx context.Context, filename string, total int64, roomID, user string) (*models.Upload, error)
+	// Write — часть с позиции offset; последняя часть запускает проверку и обработку
+	Write(ctx context.Context, id string, offset int64, part io.Reader) (*models.Upload, error)
+	Status(ctx context.Context, id string) (*models.Upload, error)
+}
@@ -0,0 +1,39 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+type UsageRecorder interface {
+	InsertUsage(ctx context.Context, rec *models.UsageRecord) error
+}
+
+type UsageRepository interface {
+	UsageRecorder
+
+	CostByMedia(ctx context.Context, from, to time.Time) ([]models.CostSummary, error)
+	CostByDay(ctx context.Context, from, to time.Time) ([]models.CostSummary, error)
+	CostByUser(ctx context.Context, from, to time.Time) ([]models.CostSummary, error)
+}
+
+// UsageScope — к чему относится вызов STT / LLM.
+// Кладётся в context оркестратором, читается клиентами при записи в ledger.
+type UsageScope struct {
+	MediaID     int
+	ChunkNumber int
+	User        string
+}
+
+type usageScopeKey struct{}
+
+func WithUsageScope(ctx context.Context, scope UsageScope) context.Context {
+	return context.WithValue(ctx, usageScopeKey{}, scope)
+}
+
+func UsageScopeFrom(ctx context.Context) UsageScope {
+	scope, _ := ctx.Value(usageScopeKey{}).(UsageScope)
+	return scope
+}
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/journalist/internal/models"
+)
+
+var (
+	ErrWatchNotFound = errors.New("watch subscription not found")
+	ErrWatchInvalid  = errors.New("invalid watch subscription")
+)
+
+type WatchRepository interface {
+	InsertWatch(ctx context.Context, w *models.WatchSubscription) error
+	UpdateWatch(ctx context.Context, w *models.WatchSubscription) error
+	DeleteWatch(ctx context.Context, id int) error
+	GetWatch(ctx context.Context, id int) (*models.WatchSubscription, error)
+	ListWatches(ctx context.Context) ([]models.WatchSubscription, error)
+	// SetWatchState — итог проверки; mediaID = 0 → не менять
+	SetWatchState(ctx context.Context, id int, liveID string, mediaID int, lastError string) error
+}
+
+type WatchManager interface {
+	Create(ctx context.Context, w *models.WatchSubscription) error
+	Update(ctx context.Context, w *models.WatchSubscription) error
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context) ([]models.WatchSubscription, error)
+	// Check — проверить канал сейчас, не дожидаясь интервала
+	Check(ctx context.Context, id int) (*models.WatchSubscription, error)
+}
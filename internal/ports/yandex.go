@@ -2,6 +2,13 @@ package ports
 
 import "context"
 
+type RecognizeOptions struct {
+	// подсказки (имена, термины) — передаются, если провайдер их поддерживает
+	Hints []string
+	// язык речи (ru-RU, en-US, ...); пусто → ru-RU
+	Language string
+}
+
 type STTService interface {
-	Recognize(ctx context.Context, wav []byte) (text string, raw []byte, err error)
+	Recognize(ctx context.Context, wav []byte, opts RecognizeOptions) (text string, raw []byte, err error)
 }
@@ -0,0 +1,2 @@
+-- откат 001_init
+DROP TABLE IF EXISTS journal_auth;
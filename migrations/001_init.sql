@@ -8,6 +8,7 @@ CREATE TABLE IF NOT EXISTS journal_auth (
     password TEXT NOT NULL
 );
 
--- Вставляем дефолтный пароль (замени при необходимости)
+-- Вставляем дефолтный пароль (замени при необходимости) — только в пустую таблицу
 INSERT INTO journal_auth (password)
-VALUES ('rossaprimavera');
\ No newline at end of file
+SELECT 'rossaprimavera'
+WHERE NOT EXISTS (SELECT 1 FROM journal_auth);
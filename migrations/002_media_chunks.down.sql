@@ -0,0 +1,3 @@
+-- откат 002_media_chunks
+DROP TABLE IF EXISTS media_chunk;
+DROP TABLE IF EXISTS media;
@@ -1,4 +1,4 @@
-CREATE TABLE media (
+CREATE TABLE IF NOT EXISTS media (
     id SERIAL PRIMARY KEY,
     source_url TEXT NOT NULL,           -- исходный URL / технический
     storage_url TEXT,                   -- nullable, URL в реальном хранилище
@@ -6,7 +6,7 @@ CREATE TABLE media (
     created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
 );
 
-CREATE TABLE media_chunk (
+CREATE TABLE IF NOT EXISTS media_chunk (
     id SERIAL PRIMARY KEY,
     media_id INT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
     chunk_number INT NOT NULL,
@@ -15,6 +15,6 @@ CREATE TABLE media_chunk (
 );
 
 ALTER TABLE media_chunk
-    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'pending',
-    ADD COLUMN file_path TEXT,
-    ADD COLUMN created_at TIMESTAMPTZ DEFAULT now();
\ No newline at end of file
+    ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS file_path TEXT,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ DEFAULT now();
\ No newline at end of file
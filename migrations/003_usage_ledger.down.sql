@@ -0,0 +1,2 @@
+-- откат 003_usage_ledger
+DROP TABLE IF EXISTS usage_ledger;
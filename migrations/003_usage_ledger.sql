@@ -0,0 +1,23 @@
+-- ====================================
+-- MIGRATION 003 — USAGE / COST LEDGER
+-- ====================================
+
+-- Каждый вызов STT / LLM: единицы, токены и оценка стоимости
+CREATE TABLE IF NOT EXISTS usage_ledger (
+    id SERIAL PRIMARY KEY,
+    media_id INT REFERENCES media(id) ON DELETE SET NULL,
+    chunk_number INT,                      -- nullable: вызовы вне чанков
+    user_name TEXT NOT NULL DEFAULT '',    -- кто запустил сессию (или room)
+    provider VARCHAR(32) NOT NULL,         -- "yandex", "openrouter", ...
+    operation VARCHAR(32) NOT NULL,        -- "stt", "chunk", ...
+    model TEXT NOT NULL DEFAULT '',
+    units INT NOT NULL DEFAULT 0,          -- тарифные единицы (Yandex: 15 сек)
+    prompt_tokens INT NOT NULL DEFAULT 0,
+    completion_tokens INT NOT NULL DEFAULT 0,
+    cost NUMERIC(14, 6) NOT NULL DEFAULT 0,
+    currency VARCHAR(8) NOT NULL DEFAULT 'USD',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS usage_ledger_media_idx ON usage_ledger (media_id, chunk_number);
+CREATE INDEX IF NOT EXISTS usage_ledger_created_idx ON usage_ledger (created_at);
@@ -0,0 +1,2 @@
+-- откат 004_glossary
+DROP TABLE IF EXISTS glossary_term;
@@ -0,0 +1,15 @@
+-- ====================================
+-- MIGRATION 004 — GLOSSARY (имена, термины)
+-- ====================================
+
+-- media_id IS NULL → термин действует для всех media (workspace)
+CREATE TABLE IF NOT EXISTS glossary_term (
+    id SERIAL PRIMARY KEY,
+    media_id INT REFERENCES media(id) ON DELETE CASCADE,
+    term TEXT NOT NULL,                       -- о чём термин: "Собянин"
+    spelling TEXT NOT NULL,                   -- как писать в тексте
+    aliases TEXT[] NOT NULL DEFAULT '{}',     -- как его слышит STT: "собянина", "со бянин"
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS glossary_term_media_idx ON glossary_term (media_id);
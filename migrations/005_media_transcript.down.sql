@@ -0,0 +1,2 @@
+-- откат 005_media_transcript
+DROP TABLE IF EXISTS media_transcript;
@@ -0,0 +1,20 @@
+-- ====================================
+-- MIGRATION 005 — TRANSCRIPT VERSIONS
+-- ====================================
+
+-- Версии полного текста media поверх живых чанков.
+-- kind = 'edited' — результат редактуры целого транскрипта.
+CREATE TABLE IF NOT EXISTS media_transcript (
+    id SERIAL PRIMARY KEY,
+    media_id INT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL DEFAULT 'edited',
+    status VARCHAR(16) NOT NULL DEFAULT 'running',   -- running | done | failed
+    text TEXT NOT NULL DEFAULT '',
+    chunk_from INT NOT NULL DEFAULT 0,
+    chunk_to INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS media_transcript_media_idx ON media_transcript (media_id, kind, id DESC);
@@ -0,0 +1,2 @@
+-- откат 006_article_draft
+DROP TABLE IF EXISTS article_draft;
@@ -0,0 +1,21 @@
+-- ====================================
+-- MIGRATION 006 — ARTICLE DRAFTS
+-- ====================================
+
+-- Черновики новостей, сгенерированные из транскрипта
+CREATE TABLE IF NOT EXISTS article_draft (
+    id SERIAL PRIMARY KEY,
+    media_id INT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    chunk_from INT NOT NULL DEFAULT 0,          -- 0 → с начала
+    chunk_to INT NOT NULL DEFAULT 0,            -- 0 → до конца
+    params JSONB NOT NULL DEFAULT '{}',         -- параметры генерации
+    headlines JSONB NOT NULL DEFAULT '[]',      -- варианты заголовка
+    lead TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    quotes JSONB NOT NULL DEFAULT '[]',         -- [{speaker, text}]
+    edited BOOLEAN NOT NULL DEFAULT false,      -- правился руками
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS article_draft_media_idx ON article_draft (media_id, id DESC);
@@ -0,0 +1,8 @@
+-- откат 007_quotes
+DROP TABLE IF EXISTS media_quote;
+
+ALTER TABLE media_chunk
+    DROP COLUMN IF EXISTS raw_text,
+    DROP COLUMN IF EXISTS captured_at,
+    DROP COLUMN IF EXISTS offset_ms,
+    DROP COLUMN IF EXISTS duration_ms;
@@ -0,0 +1,25 @@
+-- ====================================
+-- MIGRATION 007 — CHUNK TIMING + QUOTES
+-- ====================================
+
+-- Сырой текст STT и положение чанка в потоке
+ALTER TABLE media_chunk
+    ADD COLUMN IF NOT EXISTS raw_text TEXT,
+    ADD COLUMN IF NOT EXISTS captured_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS offset_ms BIGINT NOT NULL DEFAULT 0,     -- от начала media
+    ADD COLUMN IF NOT EXISTS duration_ms INT NOT NULL DEFAULT 0;
+
+-- Цитаты: дословный STT рядом с очищенным текстом + таймкоды
+CREATE TABLE IF NOT EXISTS media_quote (
+    id SERIAL PRIMARY KEY,
+    media_id INT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    chunk_number INT NOT NULL,
+    speaker TEXT NOT NULL DEFAULT '',
+    text TEXT NOT NULL,                  -- очищенная (S5)
+    verbatim TEXT NOT NULL DEFAULT '',   -- как распознал STT
+    offset_ms BIGINT NOT NULL DEFAULT 0, -- от начала media
+    duration_ms INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS media_quote_media_idx ON media_quote (media_id, offset_ms);
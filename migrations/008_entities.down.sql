@@ -0,0 +1,2 @@
+-- откат 008_entities
+DROP TABLE IF EXISTS media_entity;
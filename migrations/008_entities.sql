@@ -0,0 +1,18 @@
+-- ====================================
+-- MIGRATION 008 — NAMED ENTITIES
+-- ====================================
+
+CREATE TABLE IF NOT EXISTS media_entity (
+    id SERIAL PRIMARY KEY,
+    media_id INT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    chunk_id INT NOT NULL REFERENCES media_chunk(id) ON DELETE CASCADE,
+    chunk_number INT NOT NULL,
+    kind VARCHAR(16) NOT NULL,      -- person | organization | place | number | date | name
+    value TEXT NOT NULL,            -- как в тексте
+    normalized TEXT NOT NULL,       -- нижний регистр, начальная форма — по нему ищем
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS media_entity_normalized_idx ON media_entity (normalized text_pattern_ops);
+CREATE INDEX IF NOT EXISTS media_entity_chunk_idx ON media_entity (chunk_id);
+CREATE INDEX IF NOT EXISTS media_entity_media_idx ON media_entity (media_id, chunk_number);
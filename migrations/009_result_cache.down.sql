@@ -0,0 +1,2 @@
+-- откат 009_result_cache
+DROP TABLE IF EXISTS result_cache;
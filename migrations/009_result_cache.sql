@@ -0,0 +1,14 @@
+-- ====================================
+-- MIGRATION 009 — CONTENT-ADDRESSED RESULT CACHE
+-- ====================================
+
+-- key = sha256 входа (PCM для STT; prev+raw+prompt+model для LLM)
+CREATE TABLE IF NOT EXISTS result_cache (
+    key TEXT PRIMARY KEY,
+    kind VARCHAR(16) NOT NULL,          -- "stt" | "llm"
+    value TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS result_cache_expires_idx ON result_cache (expires_at);
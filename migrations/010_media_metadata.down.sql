@@ -0,0 +1,9 @@
+-- откат 010_media_metadata
+ALTER TABLE media
+    DROP COLUMN IF EXISTS title,
+    DROP COLUMN IF EXISTS uploader,
+    DROP COLUMN IF EXISTS duration_sec,
+    DROP COLUMN IF EXISTS is_live,
+    DROP COLUMN IF EXISTS live_status,
+    DROP COLUMN IF EXISTS thumbnail_url,
+    DROP COLUMN IF EXISTS published_at;
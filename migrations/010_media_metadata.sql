@@ -0,0 +1,13 @@
+-- ====================================
+-- MIGRATION 010 — SOURCE METADATA
+-- ====================================
+
+-- Что за источник: из yt-dlp -J
+ALTER TABLE media
+    ADD COLUMN IF NOT EXISTS title TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS uploader TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS duration_sec INT NOT NULL DEFAULT 0,         -- 0 → неизвестно / live
+    ADD COLUMN IF NOT EXISTS is_live BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS live_status VARCHAR(16) NOT NULL DEFAULT '', -- is_live, was_live, not_live, ...
+    ADD COLUMN IF NOT EXISTS thumbnail_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ;
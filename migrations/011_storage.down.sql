@@ -0,0 +1,2 @@
+-- откат 011_storage
+ALTER TABLE media_chunk DROP COLUMN IF EXISTS storage_url;
@@ -0,0 +1,7 @@
+-- ====================================
+-- MIGRATION 011 — OBJECT STORAGE
+-- ====================================
+
+-- WAV чанка в архиве (file://... или s3://bucket/key); пусто → только локальный file_path
+ALTER TABLE media_chunk
+    ADD COLUMN IF NOT EXISTS storage_url TEXT;
@@ -0,0 +1,2 @@
+-- откат 012_audio_archive
+DROP TABLE IF EXISTS media_audio_segment;
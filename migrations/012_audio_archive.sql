@@ -0,0 +1,16 @@
+-- ====================================
+-- MIGRATION 012 — LIVE AUDIO ARCHIVE
+-- ====================================
+
+-- Непрерывная запись эфира: MP3-сегменты по порядку
+CREATE TABLE IF NOT EXISTS media_audio_segment (
+    id SERIAL PRIMARY KEY,
+    media_id INT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    seq INT NOT NULL,
+    offset_ms BIGINT NOT NULL,      -- от начала media, как у чанков
+    duration_ms INT NOT NULL,
+    bytes BIGINT NOT NULL,
+    storage_url TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (media_id, seq)
+);
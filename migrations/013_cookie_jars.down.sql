@@ -0,0 +1,2 @@
+-- откат 013_cookie_jars
+DROP TABLE IF EXISTS cookie_jar;
@@ -0,0 +1,18 @@
+-- ====================================
+-- MIGRATION 013 — COOKIE JARS
+-- ====================================
+
+-- cookies.txt для yt-dlp по доменам; активен один jar на домен
+CREATE TABLE IF NOT EXISTS cookie_jar (
+    id SERIAL PRIMARY KEY,
+    domain TEXT NOT NULL,                              -- youtube.com, vk.com, ...
+    name TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL,                             -- Netscape cookies.txt
+    active BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(16) NOT NULL DEFAULT 'unchecked',   -- unchecked | ok | failed
+    last_error TEXT NOT NULL DEFAULT '',
+    checked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS cookie_jar_active_idx ON cookie_jar (domain) WHERE active;
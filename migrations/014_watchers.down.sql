@@ -0,0 +1,2 @@
+-- откат 014_watchers
+DROP TABLE IF EXISTS watch_subscription;
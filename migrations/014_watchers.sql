@@ -0,0 +1,18 @@
+-- ====================================
+-- MIGRATION 014 — CHANNEL WATCHERS
+-- ====================================
+
+-- Подписки на каналы / плейлисты: эфир начался → сессия в room
+CREATE TABLE IF NOT EXISTS watch_subscription (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,                              -- канал, /streams, /live или плейлист
+    room_id TEXT NOT NULL,
+    user_name TEXT NOT NULL DEFAULT '',             -- на кого писать расходы
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    interval_sec INT NOT NULL DEFAULT 120,
+    live_id TEXT NOT NULL DEFAULT '',               -- id текущего / последнего эфира
+    media_id INT REFERENCES media(id) ON DELETE SET NULL,
+    last_checked_at TIMESTAMPTZ,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+-- откат 015_schedules
+DROP TABLE IF EXISTS scheduled_session;
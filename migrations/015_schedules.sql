@@ -0,0 +1,26 @@
+-- ====================================
+-- MIGRATION 015 — SCHEDULED SESSIONS
+-- ====================================
+
+-- Запланированные сессии: пресс-конференции объявляются заранее
+CREATE TABLE IF NOT EXISTS scheduled_session (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    room_id TEXT NOT NULL,
+    user_name TEXT NOT NULL DEFAULT '',
+    language TEXT NOT NULL DEFAULT '',              -- ru-RU, en-US, ...; пусто → ru-RU
+    prompt TEXT NOT NULL DEFAULT '',                -- доп. указания для S5
+    start_at TIMESTAMPTZ NOT NULL,
+    max_duration_sec INT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'planned',         -- planned | waiting | running | done | failed | canceled
+    attempts INT NOT NULL DEFAULT 0,                -- попытки старта (поток ещё не поднят)
+    media_id INT REFERENCES media(id) ON DELETE SET NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_session_active
+    ON scheduled_session (start_at)
+    WHERE status IN ('planned', 'waiting', 'running');
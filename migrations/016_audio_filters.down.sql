@@ -0,0 +1,6 @@
+-- откат 016_audio_filters
+ALTER TABLE media DROP COLUMN IF EXISTS audio_filter;
+ALTER TABLE media_chunk
+    DROP COLUMN IF EXISTS audio_filter,
+    DROP COLUMN IF EXISTS rms_before_db,
+    DROP COLUMN IF EXISTS rms_after_db;
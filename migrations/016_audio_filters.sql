@@ -0,0 +1,11 @@
+-- ====================================
+-- MIGRATION 016 — AUDIO PREPROCESSING
+-- ====================================
+
+-- пресет фильтров ffmpeg перед STT; пусто → AUDIO_FILTER_PRESET
+ALTER TABLE media ADD COLUMN IF NOT EXISTS audio_filter TEXT NOT NULL DEFAULT '';
+
+-- чем обработан чанк и уровни до / после фильтров (dBFS) — чтобы сравнивать пресеты
+ALTER TABLE media_chunk ADD COLUMN IF NOT EXISTS audio_filter TEXT;
+ALTER TABLE media_chunk ADD COLUMN IF NOT EXISTS rms_before_db REAL;
+ALTER TABLE media_chunk ADD COLUMN IF NOT EXISTS rms_after_db REAL;
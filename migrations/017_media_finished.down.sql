@@ -0,0 +1,4 @@
+-- откат 017_media_finished
+ALTER TABLE media
+    DROP COLUMN IF EXISTS finished_at,
+    DROP COLUMN IF EXISTS end_reason;
@@ -0,0 +1,7 @@
+-- ====================================
+-- MIGRATION 017 — MEDIA FINISHED
+-- ====================================
+
+-- эфир кончился / запись обработана целиком; закрытие WS media не завершает
+ALTER TABLE media ADD COLUMN IF NOT EXISTS finished_at TIMESTAMPTZ;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS end_reason TEXT NOT NULL DEFAULT '';
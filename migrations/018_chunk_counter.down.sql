@@ -0,0 +1,2 @@
+-- откат 018_chunk_counter
+DROP TABLE IF EXISTS media_chunk_counter;
@@ -0,0 +1,16 @@
+-- ====================================
+-- MIGRATION 018 — CHUNK COUNTER
+-- ====================================
+
+-- номер чанка выдаётся в момент захвата окна (порядок захвата, а не
+-- порядок вставки); строка счётчика на media — без гонки MAX()+1
+CREATE TABLE IF NOT EXISTS media_chunk_counter (
+    media_id    INT PRIMARY KEY REFERENCES media(id) ON DELETE CASCADE,
+    last_number INT NOT NULL
+);
+
+INSERT INTO media_chunk_counter (media_id, last_number)
+SELECT media_id, MAX(chunk_number)
+FROM media_chunk
+GROUP BY media_id
+ON CONFLICT (media_id) DO NOTHING;
@@ -0,0 +1,3 @@
+-- откат 019_transcript_search
+DROP INDEX IF EXISTS media_chunk_text_tsv_idx;
+ALTER TABLE media_chunk DROP COLUMN IF EXISTS text_tsv;
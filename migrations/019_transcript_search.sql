@@ -0,0 +1,13 @@
+-- ====================================
+-- MIGRATION 019 — TRANSCRIPT SEARCH
+-- ====================================
+
+-- поиск по всем трансляциям: русская и английская морфология в одном векторе
+-- (|| сдвигает позиции второй части — фразы внутри каждой ищутся корректно)
+ALTER TABLE media_chunk ADD COLUMN IF NOT EXISTS text_tsv tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector('russian', COALESCE(text, '')) ||
+        to_tsvector('english', COALESCE(text, ''))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS media_chunk_text_tsv_idx ON media_chunk USING GIN (text_tsv);
@@ -0,0 +1,3 @@
+-- откат 020_article_versions
+ALTER TABLE article_draft DROP COLUMN IF EXISTS source_truncated;
+ALTER TABLE article_draft DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,10 @@
+-- ====================================
+-- MIGRATION 020 — ARTICLE VERSIONS
+-- ====================================
+
+-- регенерация правленного руками черновика создаёт новую версию
+-- (parent_id → исходный), правка не затирается
+ALTER TABLE article_draft ADD COLUMN IF NOT EXISTS parent_id INT REFERENCES article_draft(id) ON DELETE SET NULL;
+
+-- расшифровка не влезла в лимит модели — черновик написан по началу
+ALTER TABLE article_draft ADD COLUMN IF NOT EXISTS source_truncated BOOLEAN NOT NULL DEFAULT false;
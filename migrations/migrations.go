@@ -0,0 +1,8 @@
+// Package migrations — SQL-схема, вшитая в бинарник: NNN_name.sql (up) и
+// NNN_name.down.sql (откат). Применяет infra.Migrator при старте и `server migrate`
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS